@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a cached API response.
+type Entry struct {
+	Key       string    `json:"key"`
+	ETag      string    `json:"etag,omitempty"`
+	StoredAt  time.Time `json:"stored_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Body      []byte    `json:"body"`
+}
+
+// Fresh reports whether the entry is still within its lifetime at now.
+func (e *Entry) Fresh(now time.Time) bool {
+	return now.Before(e.ExpiresAt)
+}
+
+// Store is an on-disk response cache with one file per entry. It is safe for
+// concurrent use by multiple goroutines and processes.
+type Store struct {
+	dir string
+}
+
+// Open returns a store rooted at dir, creating the directory if needed.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &Store{dir: dir}, nil
+}
+
+// Dir returns the directory the store writes to.
+func (s *Store) Dir() string { return s.dir }
+
+func (s *Store) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the entry for key. Missing or unreadable entries are reported
+// as a miss.
+func (s *Store) Get(key string) (*Entry, bool) {
+	b, err := os.ReadFile(s.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var e Entry
+	if err := json.Unmarshal(b, &e); err != nil || e.Key != key {
+		return nil, false
+	}
+	return &e, true
+}
+
+// Put writes an entry atomically, replacing any previous entry for its key.
+func (s *Store) Put(e *Entry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode cache entry: %w", err)
+	}
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write cache entry: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path(e.Key)); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("commit cache entry: %w", err)
+	}
+	return nil
+}
+
+// Stats summarises the contents of a store.
+type Stats struct {
+	Dir     string    `json:"dir"`
+	Entries int       `json:"entries"`
+	Expired int       `json:"expired"`
+	Bytes   int64     `json:"bytes"`
+	Oldest  time.Time `json:"oldest,omitzero"`
+	Newest  time.Time `json:"newest,omitzero"`
+}
+
+// Stats scans the store and reports entry counts and sizes.
+func (s *Store) Stats(now time.Time) (Stats, error) {
+	st := Stats{Dir: s.dir}
+	err := s.walk(func(path string, info os.FileInfo, e *Entry) error {
+		if e == nil {
+			return nil
+		}
+		st.Entries++
+		st.Bytes += info.Size()
+		if !e.Fresh(now) {
+			st.Expired++
+		}
+		if st.Oldest.IsZero() || e.StoredAt.Before(st.Oldest) {
+			st.Oldest = e.StoredAt
+		}
+		if e.StoredAt.After(st.Newest) {
+			st.Newest = e.StoredAt
+		}
+		return nil
+	})
+	return st, err
+}
+
+// Prune removes entries that have expired at now, and any that cannot be
+// read. It returns the number of files removed.
+func (s *Store) Prune(now time.Time) (int, error) {
+	removed := 0
+	err := s.walk(func(path string, info os.FileInfo, e *Entry) error {
+		if e != nil && e.Fresh(now) {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// Purge removes every entry. It returns the number of files removed.
+func (s *Store) Purge() (int, error) {
+	removed := 0
+	err := s.walk(func(path string, info os.FileInfo, e *Entry) error {
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("remove %s: %w", path, err)
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// walk calls fn for every entry file in the store, passing a nil entry for
+// files that fail to decode.
+func (s *Store) walk(fn func(path string, info os.FileInfo, e *Entry) error) error {
+	dirEntries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read cache dir: %w", err)
+	}
+	for _, de := range dirEntries {
+		name := de.Name()
+		if de.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		path := filepath.Join(s.dir, name)
+		info, err := de.Info()
+		if err != nil {
+			continue
+		}
+		var e *Entry
+		if b, err := os.ReadFile(path); err == nil {
+			var decoded Entry
+			if json.Unmarshal(b, &decoded) == nil {
+				e = &decoded
+			}
+		}
+		if err := fn(path, info, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
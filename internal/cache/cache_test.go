@@ -0,0 +1,96 @@
+package cache_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/cache"
+)
+
+func TestStore_PutGet(t *testing.T) {
+	store, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	now := time.Now()
+	err = store.Put(&cache.Entry{
+		Key:       "https://example.test/company/00445790",
+		ETag:      `"abc"`,
+		StoredAt:  now,
+		ExpiresAt: now.Add(time.Hour),
+		Body:      []byte(`{"company_number":"00445790"}`),
+	})
+	if err != nil {
+		t.Fatalf("Put() error: %v", err)
+	}
+
+	e, ok := store.Get("https://example.test/company/00445790")
+	if !ok {
+		t.Fatal("Get() miss, want hit")
+	}
+	if e.ETag != `"abc"` {
+		t.Errorf("ETag = %q, want %q", e.ETag, `"abc"`)
+	}
+	if string(e.Body) != `{"company_number":"00445790"}` {
+		t.Errorf("Body = %s", e.Body)
+	}
+	if !e.Fresh(now) {
+		t.Error("entry should be fresh")
+	}
+	if e.Fresh(now.Add(2 * time.Hour)) {
+		t.Error("entry should have expired")
+	}
+}
+
+func TestStore_GetMiss(t *testing.T) {
+	store, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	if _, ok := store.Get("missing"); ok {
+		t.Error("Get() hit, want miss")
+	}
+}
+
+func TestStore_StatsPrunePurge(t *testing.T) {
+	dir := t.TempDir()
+	store, err := cache.Open(dir)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+
+	now := time.Now()
+	store.Put(&cache.Entry{Key: "fresh", StoredAt: now, ExpiresAt: now.Add(time.Hour)})
+	store.Put(&cache.Entry{Key: "stale", StoredAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-time.Hour)})
+	os.WriteFile(filepath.Join(dir, "corrupt.json"), []byte("not json"), 0o600)
+
+	st, err := store.Stats(now)
+	if err != nil {
+		t.Fatalf("Stats() error: %v", err)
+	}
+	if st.Entries != 2 || st.Expired != 1 {
+		t.Errorf("Stats() = %d entries, %d expired; want 2, 1", st.Entries, st.Expired)
+	}
+
+	removed, err := store.Prune(now)
+	if err != nil {
+		t.Fatalf("Prune() error: %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Prune() removed %d, want 2", removed)
+	}
+	if _, ok := store.Get("fresh"); !ok {
+		t.Error("fresh entry should survive Prune()")
+	}
+
+	removed, err = store.Purge()
+	if err != nil {
+		t.Fatalf("Purge() error: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("Purge() removed %d, want 1", removed)
+	}
+}
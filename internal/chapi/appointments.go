@@ -0,0 +1,82 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+)
+
+// AppointedTo identifies the company an appointment is held at.
+type AppointedTo struct {
+	CompanyName   string `json:"company_name"`
+	CompanyNumber string `json:"company_number"`
+	CompanyStatus string `json:"company_status,omitempty"`
+}
+
+// OfficerAppointment is one appointment held by an officer.
+type OfficerAppointment struct {
+	Name                 string            `json:"name"`
+	AppointedTo          AppointedTo       `json:"appointed_to"`
+	OfficerRole          string            `json:"officer_role"`
+	AppointedOn          string            `json:"appointed_on,omitempty"`
+	AppointedBefore      string            `json:"appointed_before,omitempty"`
+	ResignedOn           string            `json:"resigned_on,omitempty"`
+	IsPre1992Appointment bool              `json:"is_pre_1992_appointment,omitempty"`
+	Occupation           string            `json:"occupation,omitempty"`
+	Nationality          string            `json:"nationality,omitempty"`
+	CountryOfResidence   string            `json:"country_of_residence,omitempty"`
+	Address              RegisteredOffice  `json:"address"`
+	Links                map[string]string `json:"links,omitempty"`
+}
+
+// OfficerAppointmentList holds the appointments for one officer.
+type OfficerAppointmentList struct {
+	Name               string               `json:"name"`
+	DateOfBirth        *DateOfBirth         `json:"date_of_birth,omitempty"`
+	IsCorporateOfficer bool                 `json:"is_corporate_officer"`
+	TotalResults       int                  `json:"total_results"`
+	Items              []OfficerAppointment `json:"items"`
+	StartIndex         int                  `json:"start_index"`
+	ItemsPerPage       int                  `json:"items_per_page"`
+}
+
+// GetOfficerAppointments lists the appointments held by an officer across
+// all companies.
+func (c *Client) GetOfficerAppointments(ctx context.Context, officerID string, itemsPerPage, startIndex int) (*OfficerAppointmentList, error) {
+	params := url.Values{}
+	if itemsPerPage > 0 {
+		params.Set("items_per_page", fmt.Sprintf("%d", itemsPerPage))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", fmt.Sprintf("%d", startIndex))
+	}
+
+	var result OfficerAppointmentList
+	if err := c.get(ctx, fmt.Sprintf("/officers/%s/appointments", officerID), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAllOfficerAppointments fetches every page of an officer's appointments.
+// If limit is greater than zero, at most limit appointments are returned.
+func (c *Client) ListAllOfficerAppointments(ctx context.Context, officerID string, limit int) (*OfficerAppointmentList, error) {
+	var result *OfficerAppointmentList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]OfficerAppointment, int, error) {
+		page, err := c.GetOfficerAppointments(ctx, officerID, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
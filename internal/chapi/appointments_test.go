@@ -0,0 +1,89 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestGetOfficerAppointments_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/officers/aC7hZ2qM5vN9bX4cL1kJ8gF3dS6/appointments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("items_per_page"); got != "10" {
+			t.Errorf("items_per_page = %q, want %q", got, "10")
+		}
+		w.Write([]byte(`{
+			"name": "Adaeze Chioma OKAFOR",
+			"date_of_birth": {"month": 4, "year": 1986},
+			"is_corporate_officer": false,
+			"total_results": 2,
+			"items": [
+				{
+					"name": "Adaeze Chioma OKAFOR",
+					"appointed_to": {"company_name": "NORTHWIND ANALYTICS LTD", "company_number": "12987654", "company_status": "active"},
+					"officer_role": "director",
+					"appointed_on": "2020-11-03"
+				},
+				{
+					"name": "Adaeze Chioma OKAFOR",
+					"appointed_to": {"company_name": "NORTHWIND HOLDINGS LIMITED", "company_number": "13876501", "company_status": "active"},
+					"officer_role": "director",
+					"appointed_on": "2022-01-20"
+				}
+			]
+		}`))
+	})
+
+	result, err := client.GetOfficerAppointments(context.Background(), "aC7hZ2qM5vN9bX4cL1kJ8gF3dS6", 10, 0)
+	if err != nil {
+		t.Fatalf("GetOfficerAppointments() error: %v", err)
+	}
+	if result.DateOfBirth == nil || result.DateOfBirth.Year != 1986 {
+		t.Errorf("DateOfBirth = %+v, want year 1986", result.DateOfBirth)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("Items count = %d, want 2", len(result.Items))
+	}
+	if got := result.Items[1].AppointedTo.CompanyNumber; got != "13876501" {
+		t.Errorf("Items[1].AppointedTo.CompanyNumber = %q, want %q", got, "13876501")
+	}
+}
+
+func TestOfficerID(t *testing.T) {
+	tests := []struct {
+		name  string
+		links chapi.OfficerLinks
+		want  string
+	}{
+		{
+			name: "company officer list",
+			links: chapi.OfficerLinks{
+				Self:    "/company/12987654/appointments/Zx9",
+				Officer: chapi.OfficerLinkRefs{Appointments: "/officers/aC7hZ2q/appointments"},
+			},
+			want: "aC7hZ2q",
+		},
+		{
+			name:  "officer search result",
+			links: chapi.OfficerLinks{Self: "/officers/tH9jK3l/appointments"},
+			want:  "tH9jK3l",
+		},
+		{
+			name:  "no officer link",
+			links: chapi.OfficerLinks{Self: "/company/12987654/appointments/Zx9"},
+			want:  "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := chapi.Officer{Links: tt.links}
+			if got := o.OfficerID(); got != tt.want {
+				t.Errorf("OfficerID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package chapi
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/cache"
+)
+
+// ErrNotCached is returned in offline mode when a response is not cached.
+var ErrNotCached = errors.New("response not in cache (offline mode)")
+
+// CacheOptions controls how a Client uses an on-disk response cache.
+type CacheOptions struct {
+	Store *cache.Store
+	// TTL overrides the per-resource lifetimes when greater than zero.
+	TTL time.Duration
+	// Offline serves responses only from the cache, whatever their age.
+	Offline bool
+}
+
+// resourceTTL returns how long a response for path stays fresh. Search results
+// and filing history change most often; officer, PSC and charge registers
+// rarely change within a day.
+func resourceTTL(path string) time.Duration {
+	switch {
+	case strings.HasPrefix(path, "/search"), strings.HasSuffix(path, "-search/companies"):
+		return time.Hour
+	case strings.Contains(path, "/filing-history"):
+		return time.Hour
+	case strings.HasPrefix(path, "/company/") && strings.Count(path, "/") == 2:
+		return 6 * time.Hour
+	default:
+		return 24 * time.Hour
+	}
+}
+
+// fresh reports whether a cached entry can be served without revalidation.
+func (c *Client) fresh(e *cache.Entry, now time.Time) bool {
+	if c.cache.TTL > 0 {
+		return now.Before(e.StoredAt.Add(c.cache.TTL))
+	}
+	return e.Fresh(now)
+}
+
+// storeResponse writes a successful response to the cache, if one is set.
+// Cache write failures are logged and otherwise ignored.
+func (c *Client) storeResponse(path, key, etag string, body []byte) {
+	if c.cache.Store == nil {
+		return
+	}
+	ttl := c.cache.TTL
+	if ttl <= 0 {
+		ttl = resourceTTL(path)
+	}
+	now := time.Now()
+	err := c.cache.Store.Put(&cache.Entry{
+		Key:       key,
+		ETag:      etag,
+		StoredAt:  now,
+		ExpiresAt: now.Add(ttl),
+		Body:      body,
+	})
+	if err != nil {
+		slog.Debug("cache write failed", "key", key, "err", err)
+	}
+}
@@ -0,0 +1,88 @@
+package chapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/cache"
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func cachedClient(t *testing.T, handler http.HandlerFunc, ttl time.Duration) *chapi.Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	store, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("cache.Open() error: %v", err)
+	}
+	return chapi.NewWithBaseURL("test-api-key", srv.URL, chapi.WithCache(chapi.CacheOptions{Store: store, TTL: ttl}))
+}
+
+func TestClient_CacheServesFreshResponses(t *testing.T) {
+	calls := 0
+	client := cachedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"company_name": "TESCO PLC"}`))
+	}, 0)
+
+	for range 2 {
+		profile, err := client.GetCompany(context.Background(), "00445790")
+		if err != nil {
+			t.Fatalf("GetCompany() error: %v", err)
+		}
+		if profile.CompanyName != "TESCO PLC" {
+			t.Errorf("CompanyName = %q, want %q", profile.CompanyName, "TESCO PLC")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestClient_CacheRevalidatesWithETag(t *testing.T) {
+	calls := 0
+	client := cachedClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"company_name": "TESCO PLC"}`))
+	}, time.Nanosecond)
+
+	for range 2 {
+		profile, err := client.GetCompany(context.Background(), "00445790")
+		if err != nil {
+			t.Fatalf("GetCompany() error: %v", err)
+		}
+		if profile.CompanyName != "TESCO PLC" {
+			t.Errorf("CompanyName = %q, want %q", profile.CompanyName, "TESCO PLC")
+		}
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestClient_OfflineMiss(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("offline client should not reach the network")
+	}))
+	t.Cleanup(srv.Close)
+	store, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("cache.Open() error: %v", err)
+	}
+	client := chapi.NewWithBaseURL("test-api-key", srv.URL, chapi.WithCache(chapi.CacheOptions{Store: store, Offline: true}))
+
+	_, err = client.GetCompany(context.Background(), "00445790")
+	if !errors.Is(err, chapi.ErrNotCached) {
+		t.Errorf("err = %v, want ErrNotCached", err)
+	}
+}
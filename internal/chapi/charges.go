@@ -4,21 +4,94 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // Charge represents a company charge (mortgage/security).
 type Charge struct {
+	ID                  string `json:"id,omitempty"`
 	ChargeCode          string `json:"charge_code"`
-	Classification      map[string]string `json:"classification"`
+	ChargeNumber        int    `json:"charge_number,omitempty"`
+	Classification      ChargeClassification `json:"classification"`
 	Status              string `json:"status"`
 	DeliveredOn         string `json:"delivered_on"`
 	CreatedOn           string `json:"created_on,omitempty"`
 	SatisfiedOn         string `json:"satisfied_on,omitempty"`
-	PersonsEntitled     []map[string]string `json:"persons_entitled,omitempty"`
-	Particulars         map[string]any `json:"particulars,omitempty"`
+	AcquiredOn          string `json:"acquired_on,omitempty"`
+	ResolvedOn          string `json:"resolved_on,omitempty"`
+	CoveringInstrumentDate string `json:"covering_instrument_date,omitempty"`
+	AssetsCeasedReleased string `json:"assets_ceased_released,omitempty"`
+	PersonsEntitled     []PersonEntitled `json:"persons_entitled,omitempty"`
+	MoreThanFourPersonsEntitled bool `json:"more_than_four_persons_entitled,omitempty"`
+	Particulars         *ChargeParticulars `json:"particulars,omitempty"`
+	SecuredDetails      *SecuredDetails `json:"secured_details,omitempty"`
+	Transactions        []ChargeTransaction `json:"transactions,omitempty"`
+	InsolvencyCases     []ChargeInsolvencyCase `json:"insolvency_cases,omitempty"`
 	Links               map[string]string `json:"links,omitempty"`
 }
 
+// ChargeClassification describes what kind of charge was registered.
+type ChargeClassification struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// ChargeParticulars describes the property the charge is over.
+type ChargeParticulars struct {
+	Type                       string `json:"type,omitempty"`
+	Description                string `json:"description,omitempty"`
+	ContainsFixedCharge        bool   `json:"contains_fixed_charge,omitempty"`
+	ContainsFloatingCharge     bool   `json:"contains_floating_charge,omitempty"`
+	FloatingChargeCoversAll    bool   `json:"floating_charge_covers_all,omitempty"`
+	ContainsNegativePledge     bool   `json:"contains_negative_pledge,omitempty"`
+	ChargorActingAsBareTrustee bool   `json:"chargor_acting_as_bare_trustee,omitempty"`
+}
+
+// SecuredDetails describes the obligations the charge secures.
+type SecuredDetails struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+}
+
+// PersonEntitled is a lender or trustee entitled to the charge.
+type PersonEntitled struct {
+	Name string `json:"name"`
+}
+
+// ChargeTransaction is a filing made against a charge, such as its creation
+// or a statement of satisfaction.
+type ChargeTransaction struct {
+	FilingType           string                 `json:"filing_type"`
+	TransactionID        int                    `json:"transaction_id,omitempty"`
+	DeliveredOn          string                 `json:"delivered_on"`
+	InsolvencyCaseNumber int                    `json:"insolvency_case_number,omitempty"`
+	Links                ChargeTransactionLinks `json:"links,omitzero"`
+}
+
+// ChargeTransactionLinks points at the filing and any insolvency case behind
+// a charge transaction.
+type ChargeTransactionLinks struct {
+	Filing         string `json:"filing,omitempty"`
+	InsolvencyCase string `json:"insolvency_case,omitempty"`
+}
+
+// ChargeInsolvencyCase is an insolvency case the charge is involved in.
+type ChargeInsolvencyCase struct {
+	CaseNumber    int               `json:"case_number"`
+	TransactionID int               `json:"transaction_id,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+}
+
+// ChargeIDFromLink returns the charge ID at the end of a charge self link
+// such as /company/{number}/charges/{id}, or "" if link is not one.
+func ChargeIDFromLink(link string) string {
+	_, id, ok := strings.Cut(link, "/charges/")
+	if !ok || strings.Contains(id, "/") {
+		return ""
+	}
+	return id
+}
+
 // ChargeList holds a list of charges.
 type ChargeList struct {
 	TotalCount    int      `json:"total_count"`
@@ -44,3 +117,33 @@ func (c *Client) ListCharges(ctx context.Context, companyNumber string, itemsPer
 	}
 	return &result, nil
 }
+
+// GetCharge retrieves a single charge with its filing transactions.
+func (c *Client) GetCharge(ctx context.Context, companyNumber, chargeID string) (*Charge, error) {
+	var result Charge
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/charges/%s", companyNumber, chargeID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAllCharges fetches every page of charges for a company. If limit is
+// greater than zero, at most limit charges are returned.
+func (c *Client) ListAllCharges(ctx context.Context, companyNumber string, limit int) (*ChargeList, error) {
+	var result *ChargeList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]Charge, int, error) {
+		page, err := c.ListCharges(ctx, companyNumber, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalCount, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	return result, nil
+}
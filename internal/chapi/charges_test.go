@@ -52,3 +52,60 @@ func TestListCharges_Success(t *testing.T) {
 		t.Errorf("Items[1].SatisfiedOn = %q, want %q", result.Items[1].SatisfiedOn, "2023-01-10")
 	}
 }
+
+func TestGetCharge_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/04567321/charges/mN1bV2c" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"charge_code": "045673210001",
+			"charge_number": 1,
+			"classification": {"type": "nature-of-charge", "description": "Debenture"},
+			"status": "outstanding",
+			"delivered_on": "2015-05-21",
+			"persons_entitled": [{"name": "Lloyds Bank PLC"}],
+			"particulars": {
+				"type": "short-particulars",
+				"description": "Fixed and floating charge over the undertaking.",
+				"contains_fixed_charge": true,
+				"floating_charge_covers_all": true
+			},
+			"secured_details": {"type": "amount-secured", "description": "All monies due"},
+			"transactions": [
+				{"filing_type": "create-charge-pre-2006-companies-act", "delivered_on": "2015-05-21", "links": {"filing": "/company/04567321/filing-history/abc"}},
+				{"filing_type": "charge-satisfaction", "delivered_on": "2024-05-01", "insolvency_case_number": 1}
+			],
+			"insolvency_cases": [{"case_number": 1, "links": {"case": "/company/04567321/insolvency#1"}}]
+		}`))
+	})
+
+	ch, err := client.GetCharge(context.Background(), "04567321", "mN1bV2c")
+	if err != nil {
+		t.Fatalf("GetCharge() error: %v", err)
+	}
+	if ch.Classification.Description != "Debenture" {
+		t.Errorf("Classification.Description = %q, want %q", ch.Classification.Description, "Debenture")
+	}
+	if ch.Particulars == nil || !ch.Particulars.ContainsFixedCharge || !ch.Particulars.FloatingChargeCoversAll {
+		t.Errorf("Particulars = %+v", ch.Particulars)
+	}
+	if ch.SecuredDetails == nil || ch.SecuredDetails.Type != "amount-secured" {
+		t.Errorf("SecuredDetails = %+v", ch.SecuredDetails)
+	}
+	if len(ch.PersonsEntitled) != 1 || ch.PersonsEntitled[0].Name != "Lloyds Bank PLC" {
+		t.Errorf("PersonsEntitled = %+v", ch.PersonsEntitled)
+	}
+	if len(ch.Transactions) != 2 {
+		t.Fatalf("Transactions count = %d, want 2", len(ch.Transactions))
+	}
+	if ch.Transactions[0].Links.Filing != "/company/04567321/filing-history/abc" {
+		t.Errorf("Transactions[0].Links.Filing = %q", ch.Transactions[0].Links.Filing)
+	}
+	if ch.Transactions[1].InsolvencyCaseNumber != 1 {
+		t.Errorf("Transactions[1].InsolvencyCaseNumber = %d, want 1", ch.Transactions[1].InsolvencyCaseNumber)
+	}
+	if len(ch.InsolvencyCases) != 1 || ch.InsolvencyCases[0].CaseNumber != 1 {
+		t.Errorf("InsolvencyCases = %+v", ch.InsolvencyCases)
+	}
+}
@@ -8,64 +8,97 @@ import (
 	"net/http"
 	"net/url"
 	"time"
-)
 
-const (
-	baseURL    = "https://api.company-information.service.gov.uk"
-	maxRetries = 3
+	"github.com/anthonyencodeclub/ch/internal/cache"
 )
 
+const baseURL = "https://api.company-information.service.gov.uk"
+
 // Client wraps the Companies House REST API.
 type Client struct {
 	apiKey     string
 	httpClient *http.Client
 	baseURL    string
+	limiter    *RateLimiter
+	cache      CacheOptions
+	opts       options
 }
 
-// New creates a new Companies House API client.
-func New(apiKey string) *Client {
+// New creates a new Companies House API client. Without options it talks to
+// the live API with a 30s timeout, three attempts per request and the rate
+// limiter shared by every client in the process.
+func New(apiKey string, opts ...Option) *Client {
+	o := newOptions(baseURL, opts)
 	return &Client{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: baseURL,
+		apiKey:     apiKey,
+		httpClient: o.buildHTTPClient(),
+		baseURL:    o.baseURL,
+		limiter:    o.limiter,
+		cache:      o.cache,
+		opts:       o,
 	}
 }
 
 // NewWithBaseURL creates a client with a custom base URL (useful for testing).
-func NewWithBaseURL(apiKey, base string) *Client {
-	return &Client{
-		apiKey: apiKey,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		baseURL: base,
-	}
+// It gets its own rate limiter so tests do not share quota state.
+func NewWithBaseURL(apiKey, base string, opts ...Option) *Client {
+	opts = append([]Option{WithBaseURL(base), WithRateLimiter(NewRateLimiter(defaultRateLimit, defaultRateWindow))}, opts...)
+	return New(apiKey, opts...)
+}
+
+// RateLimiter returns the limiter pacing this client's requests.
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
 }
 
-// doRequest performs an authenticated GET request with retries.
+// doRequest performs an authenticated GET request with retries. Fresh cached
+// responses are returned without touching the network and stale ones are
+// revalidated with their ETag. Requests are paced by the client's rate
+// limiter, and a 429 response waits for the quota to reset before retrying.
 func (c *Client) doRequest(ctx context.Context, path string, query url.Values) ([]byte, error) {
 	u := c.baseURL + path
 	if len(query) > 0 {
 		u += "?" + query.Encode()
 	}
 
+	var cached *cache.Entry
+	if c.cache.Store != nil {
+		if e, ok := c.cache.Store.Get(u); ok {
+			if c.cache.Offline || c.fresh(e, time.Now()) {
+				return e.Body, nil
+			}
+			cached = e
+		}
+	}
+	if c.cache.Offline {
+		return nil, ErrNotCached
+	}
+
 	var lastErr error
-	for attempt := range maxRetries {
+	for attempt := range c.opts.retry.MaxAttempts {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 		if err != nil {
 			return nil, fmt.Errorf("create request: %w", err)
 		}
 		req.SetBasicAuth(c.apiKey, "")
+		c.opts.prepare(req)
+		if cached != nil && cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
 
 		resp, err := c.httpClient.Do(req)
 		if err != nil {
 			lastErr = fmt.Errorf("request failed: %w", err)
-			time.Sleep(time.Duration(attempt+1) * 500 * time.Millisecond)
+			time.Sleep(c.opts.retry.Backoff(attempt))
 			continue
 		}
 
+		c.limiter.Observe(resp.Header)
+
 		body, err := io.ReadAll(resp.Body)
 		resp.Body.Close()
 		if err != nil {
@@ -73,18 +106,21 @@ func (c *Client) doRequest(ctx context.Context, path string, query url.Values) (
 		}
 
 		if resp.StatusCode == http.StatusTooManyRequests {
-			lastErr = fmt.Errorf("rate limited (429)")
-			time.Sleep(time.Duration(attempt+1) * time.Second)
+			lastErr = newAPIError(resp.StatusCode, body)
+			c.limiter.exhaust(time.Now(), time.Duration(attempt+1)*time.Second)
 			continue
 		}
 
+		if resp.StatusCode == http.StatusNotModified && cached != nil {
+			c.storeResponse(path, u, cached.ETag, cached.Body)
+			return cached.Body, nil
+		}
+
 		if resp.StatusCode != http.StatusOK {
-			return nil, &APIError{
-				StatusCode: resp.StatusCode,
-				Body:       string(body),
-			}
+			return nil, newAPIError(resp.StatusCode, body)
 		}
 
+		c.storeResponse(path, u, resp.Header.Get("ETag"), body)
 		return body, nil
 	}
 	return nil, lastErr
@@ -101,13 +137,3 @@ func (c *Client) get(ctx context.Context, path string, query url.Values, out any
 	}
 	return nil
 }
-
-// APIError represents an error response from the API.
-type APIError struct {
-	StatusCode int
-	Body       string
-}
-
-func (e *APIError) Error() string {
-	return fmt.Sprintf("Companies House API error (HTTP %d): %s", e.StatusCode, e.Body)
-}
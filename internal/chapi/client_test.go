@@ -2,6 +2,8 @@ package chapi_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -63,6 +65,74 @@ func TestAPIError_Error(t *testing.T) {
 	}
 }
 
+func TestClient_APIErrorDecoded(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"errors":[{"error":"items_per_page must be a number","type":"ch:validation","location":"items_per_page","location_type":"request-parameter"}]}`))
+	})
+
+	_, err := client.ListOfficers(context.Background(), "00445790", 10, 0)
+	var apiErr *chapi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if len(apiErr.Errors) != 1 {
+		t.Fatalf("Errors count = %d, want 1", len(apiErr.Errors))
+	}
+	d := apiErr.Errors[0]
+	if d.Type != "ch:validation" || d.Location != "items_per_page" || d.LocationType != "request-parameter" {
+		t.Errorf("Errors[0] = %+v", d)
+	}
+	if !errors.Is(err, chapi.ErrValidation) {
+		t.Error("expected errors.Is(err, ErrValidation)")
+	}
+	if !contains(err.Error(), "items_per_page must be a number") {
+		t.Errorf("Error() = %q, should contain the API message", err.Error())
+	}
+}
+
+func TestAPIError_SingleErrorObject(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error":"Invalid Authorization","type":"ch:service"}`))
+	})
+
+	_, err := client.GetCompany(context.Background(), "00445790")
+	var apiErr *chapi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %T", err)
+	}
+	if len(apiErr.Errors) != 1 || apiErr.Errors[0].Error != "Invalid Authorization" {
+		t.Errorf("Errors = %+v", apiErr.Errors)
+	}
+	if !errors.Is(err, chapi.ErrUnauthorized) {
+		t.Error("expected errors.Is(err, ErrUnauthorized)")
+	}
+}
+
+func TestAPIError_Is(t *testing.T) {
+	tests := []struct {
+		status int
+		target error
+	}{
+		{http.StatusNotFound, chapi.ErrNotFound},
+		{http.StatusUnauthorized, chapi.ErrUnauthorized},
+		{http.StatusForbidden, chapi.ErrUnauthorized},
+		{http.StatusTooManyRequests, chapi.ErrRateLimited},
+		{http.StatusUnprocessableEntity, chapi.ErrValidation},
+		{http.StatusBadGateway, chapi.ErrServer},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("wrapped: %w", &chapi.APIError{StatusCode: tt.status})
+		if !errors.Is(err, tt.target) {
+			t.Errorf("HTTP %d: errors.Is(%v) = false, want true", tt.status, tt.target)
+		}
+	}
+	if errors.Is(&chapi.APIError{StatusCode: http.StatusNotFound}, chapi.ErrServer) {
+		t.Error("HTTP 404 should not match ErrServer")
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && searchString(s, substr)
 }
@@ -21,9 +21,17 @@ type CompanyProfile struct {
 	HasInsolvencyHistory bool          `json:"has_insolvency_history"`
 	Accounts           *Accounts       `json:"accounts,omitempty"`
 	ConfirmationStatement *ConfirmationStatement `json:"confirmation_statement,omitempty"`
+	PreviousCompanyNames []PreviousCompanyName `json:"previous_company_names,omitempty"`
 	Links              map[string]string `json:"links,omitempty"`
 }
 
+// PreviousCompanyName is a name a company was registered under before.
+type PreviousCompanyName struct {
+	Name          string `json:"name"`
+	EffectiveFrom string `json:"effective_from,omitempty"`
+	CeasedOn      string `json:"ceased_on,omitempty"`
+}
+
 // RegisteredOffice represents a company's registered address.
 type RegisteredOffice struct {
 	AddressLine1 string `json:"address_line_1"`
@@ -103,3 +111,26 @@ func (c *Client) SearchCompanies(ctx context.Context, query string, itemsPerPage
 	}
 	return &result, nil
 }
+
+// SearchAllCompanies fetches every page of company search results. If limit is
+// greater than zero, at most limit results are returned.
+func (c *Client) SearchAllCompanies(ctx context.Context, query string, limit int) (*SearchResult, error) {
+	var result *SearchResult
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]CompanyProfile, int, error) {
+		page, err := c.SearchCompanies(ctx, query, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
@@ -3,7 +3,11 @@ package chapi_test
 import (
 	"context"
 	"net/http"
+	"path/filepath"
 	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/vcr"
 )
 
 func TestGetCompany_Success(t *testing.T) {
@@ -52,6 +56,28 @@ func TestGetCompany_Success(t *testing.T) {
 	}
 }
 
+func TestGetCompany_Cassette(t *testing.T) {
+	rep, err := vcr.NewReplayer(filepath.Join("testdata", "tesco", vcr.CassetteFile))
+	if err != nil {
+		t.Fatalf("NewReplayer() error: %v", err)
+	}
+	client := chapi.NewWithBaseURL("test-api-key", "https://api.company-information.service.gov.uk", chapi.WithTransport(rep))
+
+	profile, err := client.GetCompany(context.Background(), "00445790")
+	if err != nil {
+		t.Fatalf("GetCompany() error: %v", err)
+	}
+	if profile.CompanyName != "TESCO PLC" {
+		t.Errorf("CompanyName = %q, want %q", profile.CompanyName, "TESCO PLC")
+	}
+	if profile.Accounts == nil || profile.Accounts.LastAccounts == nil || profile.Accounts.LastAccounts.Type != "group" {
+		t.Errorf("Accounts = %+v, want last accounts of type group", profile.Accounts)
+	}
+	if !profile.HasCharges {
+		t.Error("HasCharges = false, want true")
+	}
+}
+
 func TestGetCompany_NotFound(t *testing.T) {
 	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNotFound)
@@ -0,0 +1,198 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DisqualifiedOfficerSearchItem is one result from a disqualified officer
+// search.
+type DisqualifiedOfficerSearchItem struct {
+	Kind           string            `json:"kind"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description,omitempty"`
+	DateOfBirth    string            `json:"date_of_birth,omitempty"`
+	Address        RegisteredOffice  `json:"address"`
+	AddressSnippet string            `json:"address_snippet,omitempty"`
+	Snippet        string            `json:"snippet,omitempty"`
+	Links          map[string]string `json:"links,omitempty"`
+}
+
+// OfficerKind returns "natural" or "corporate" and the officer ID taken from
+// the item's self link, which is the form GetNaturalDisqualifiedOfficer and
+// GetCorporateDisqualifiedOfficer expect. ok is false if the link is missing
+// or malformed.
+func (d DisqualifiedOfficerSearchItem) OfficerKind() (kind, id string, ok bool) {
+	return ParseDisqualifiedOfficerLink(d.Links["self"])
+}
+
+// ParseDisqualifiedOfficerLink splits a link of the form
+// /disqualified-officers/{natural|corporate}/{id}.
+func ParseDisqualifiedOfficerLink(link string) (kind, id string, ok bool) {
+	rest, found := strings.CutPrefix(link, "/disqualified-officers/")
+	if !found {
+		return "", "", false
+	}
+	kind, id, found = strings.Cut(rest, "/")
+	if !found || id == "" || (kind != "natural" && kind != "corporate") {
+		return "", "", false
+	}
+	return kind, id, true
+}
+
+// DisqualifiedOfficerSearchResult holds disqualified officer search results.
+type DisqualifiedOfficerSearchResult struct {
+	TotalResults int                             `json:"total_results"`
+	Items        []DisqualifiedOfficerSearchItem `json:"items"`
+	StartIndex   int                             `json:"start_index"`
+	ItemsPerPage int                             `json:"items_per_page"`
+}
+
+// DisqualificationReason is the legislation a disqualification was made
+// under.
+type DisqualificationReason struct {
+	DescriptionIdentifier string `json:"description_identifier"`
+	Act                   string `json:"act"`
+	Article               string `json:"article,omitempty"`
+	Section               string `json:"section,omitempty"`
+}
+
+// DisqualificationVariation records a court varying a disqualification.
+type DisqualificationVariation struct {
+	CaseIdentifier string `json:"case_identifier,omitempty"`
+	CourtName      string `json:"court_name,omitempty"`
+	VariedOn       string `json:"varied_on,omitempty"`
+}
+
+// DisqualificationAddress is the address recorded against a
+// disqualification. Unlike a registered office it may carry a premises name
+// or number separately from the first address line.
+type DisqualificationAddress struct {
+	RegisteredOffice
+	Premises string `json:"premises,omitempty"`
+}
+
+// Disqualification is one disqualification order or undertaking.
+type Disqualification struct {
+	CaseIdentifier       string                      `json:"case_identifier,omitempty"`
+	DisqualificationType string                      `json:"disqualification_type"`
+	DisqualifiedFrom     string                      `json:"disqualified_from"`
+	DisqualifiedUntil    string                      `json:"disqualified_until"`
+	HeardOn              string                      `json:"heard_on,omitempty"`
+	UndertakenOn         string                      `json:"undertaken_on,omitempty"`
+	CourtName            string                      `json:"court_name,omitempty"`
+	CompanyNames         []string                    `json:"company_names,omitempty"`
+	Address              DisqualificationAddress     `json:"address"`
+	Reason               DisqualificationReason      `json:"reason"`
+	LastVariation        []DisqualificationVariation `json:"last_variation,omitempty"`
+}
+
+// PermissionToAct is a court's permission for a disqualified officer to act
+// for named companies despite the disqualification.
+type PermissionToAct struct {
+	CompanyNames []string `json:"company_names,omitempty"`
+	CourtName    string   `json:"court_name,omitempty"`
+	GrantedOn    string   `json:"granted_on"`
+	ExpiresOn    string   `json:"expires_on,omitempty"`
+	Purpose      string   `json:"purpose,omitempty"`
+}
+
+// NaturalDisqualifiedOfficer is a disqualified individual.
+type NaturalDisqualifiedOfficer struct {
+	Kind              string             `json:"kind"`
+	Title             string             `json:"title,omitempty"`
+	Forename          string             `json:"forename,omitempty"`
+	OtherForenames    string             `json:"other_forenames,omitempty"`
+	Surname           string             `json:"surname"`
+	Honours           string             `json:"honours,omitempty"`
+	DateOfBirth       string             `json:"date_of_birth,omitempty"`
+	Nationality       string             `json:"nationality,omitempty"`
+	Disqualifications []Disqualification `json:"disqualifications"`
+	PermissionsToAct  []PermissionToAct  `json:"permissions_to_act,omitempty"`
+	Links             map[string]string  `json:"links,omitempty"`
+}
+
+// Name returns the officer's full name in display order.
+func (o NaturalDisqualifiedOfficer) Name() string {
+	var parts []string
+	for _, p := range []string{o.Title, o.Forename, o.OtherForenames, o.Surname, o.Honours} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// CorporateDisqualifiedOfficer is a disqualified corporate body.
+type CorporateDisqualifiedOfficer struct {
+	Kind                  string             `json:"kind"`
+	Name                  string             `json:"name"`
+	CompanyNumber         string             `json:"company_number,omitempty"`
+	CountryOfRegistration string             `json:"country_of_registration,omitempty"`
+	Disqualifications     []Disqualification `json:"disqualifications"`
+	PermissionsToAct      []PermissionToAct  `json:"permissions_to_act,omitempty"`
+	Links                 map[string]string  `json:"links,omitempty"`
+}
+
+// SearchDisqualifiedOfficers searches the disqualified officers register.
+func (c *Client) SearchDisqualifiedOfficers(ctx context.Context, query string, itemsPerPage, startIndex int) (*DisqualifiedOfficerSearchResult, error) {
+	params := url.Values{
+		"q": {query},
+	}
+	if itemsPerPage > 0 {
+		params.Set("items_per_page", fmt.Sprintf("%d", itemsPerPage))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", fmt.Sprintf("%d", startIndex))
+	}
+
+	var result DisqualifiedOfficerSearchResult
+	if err := c.get(ctx, "/search/disqualified-officers", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAllDisqualifiedOfficers fetches every page of disqualified officer
+// search results. If limit is greater than zero, at most limit results are
+// returned.
+func (c *Client) SearchAllDisqualifiedOfficers(ctx context.Context, query string, limit int) (*DisqualifiedOfficerSearchResult, error) {
+	var result *DisqualifiedOfficerSearchResult
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]DisqualifiedOfficerSearchItem, int, error) {
+		page, err := c.SearchDisqualifiedOfficers(ctx, query, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
+
+// GetNaturalDisqualifiedOfficer retrieves a disqualified individual.
+func (c *Client) GetNaturalDisqualifiedOfficer(ctx context.Context, officerID string) (*NaturalDisqualifiedOfficer, error) {
+	var result NaturalDisqualifiedOfficer
+	if err := c.get(ctx, fmt.Sprintf("/disqualified-officers/natural/%s", officerID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCorporateDisqualifiedOfficer retrieves a disqualified corporate body.
+func (c *Client) GetCorporateDisqualifiedOfficer(ctx context.Context, officerID string) (*CorporateDisqualifiedOfficer, error) {
+	var result CorporateDisqualifiedOfficer
+	if err := c.get(ctx, fmt.Sprintf("/disqualified-officers/corporate/%s", officerID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,146 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestSearchDisqualifiedOfficers_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/disqualified-officers" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if q := r.URL.Query().Get("q"); q != "price" {
+			t.Errorf("q = %q, want %q", q, "price")
+		}
+		w.Write([]byte(`{
+			"total_results": 1,
+			"items": [
+				{
+					"kind": "searchresults#disqualified-officer",
+					"title": "Gareth Wyn PRICE",
+					"date_of_birth": "1971-08-14",
+					"address": {"address_line_1": "Canal Street", "locality": "Manchester"},
+					"links": {"self": "/disqualified-officers/natural/gP7wQ2x"}
+				}
+			]
+		}`))
+	})
+
+	result, err := client.SearchDisqualifiedOfficers(context.Background(), "price", 0, 0)
+	if err != nil {
+		t.Fatalf("SearchDisqualifiedOfficers() error: %v", err)
+	}
+	if len(result.Items) != 1 {
+		t.Fatalf("Items count = %d, want 1", len(result.Items))
+	}
+	kind, id, ok := result.Items[0].OfficerKind()
+	if !ok || kind != "natural" || id != "gP7wQ2x" {
+		t.Errorf("OfficerKind() = %q, %q, %v; want natural, gP7wQ2x, true", kind, id, ok)
+	}
+}
+
+func TestGetNaturalDisqualifiedOfficer_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/disqualified-officers/natural/gP7wQ2x" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"kind": "natural-disqualification",
+			"title": "Mr",
+			"forename": "Gareth",
+			"other_forenames": "Wyn",
+			"surname": "PRICE",
+			"date_of_birth": "1971-08-14",
+			"disqualifications": [
+				{
+					"disqualification_type": "court-order",
+					"disqualified_from": "2023-03-02",
+					"disqualified_until": "2030-03-01",
+					"company_names": ["OLD MILL TRADING LIMITED"],
+					"address": {"premises": "12", "address_line_1": "Canal Street", "locality": "Manchester"},
+					"reason": {"description_identifier": "order-or-undertaking-and-reason", "act": "company-directors-disqualification-act-1986", "section": "6"},
+					"last_variation": [{"varied_on": "2024-01-10", "court_name": "Court of Appeal"}]
+				}
+			],
+			"permissions_to_act": [
+				{"company_names": ["PRICE JOINERY LIMITED"], "granted_on": "2023-06-15", "expires_on": "2025-06-14"}
+			]
+		}`))
+	})
+
+	officer, err := client.GetNaturalDisqualifiedOfficer(context.Background(), "gP7wQ2x")
+	if err != nil {
+		t.Fatalf("GetNaturalDisqualifiedOfficer() error: %v", err)
+	}
+	if got := officer.Name(); got != "Mr Gareth Wyn PRICE" {
+		t.Errorf("Name() = %q, want %q", got, "Mr Gareth Wyn PRICE")
+	}
+	if len(officer.Disqualifications) != 1 {
+		t.Fatalf("Disqualifications count = %d, want 1", len(officer.Disqualifications))
+	}
+	d := officer.Disqualifications[0]
+	if d.Reason.Section != "6" {
+		t.Errorf("Reason.Section = %q, want %q", d.Reason.Section, "6")
+	}
+	if d.Address.Premises != "12" || d.Address.AddressLine1 != "Canal Street" {
+		t.Errorf("Address = %+v", d.Address)
+	}
+	if len(d.LastVariation) != 1 || d.LastVariation[0].VariedOn != "2024-01-10" {
+		t.Errorf("LastVariation = %+v", d.LastVariation)
+	}
+	if len(officer.PermissionsToAct) != 1 || officer.PermissionsToAct[0].ExpiresOn != "2025-06-14" {
+		t.Errorf("PermissionsToAct = %+v", officer.PermissionsToAct)
+	}
+}
+
+func TestGetCorporateDisqualifiedOfficer_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/disqualified-officers/corporate/mS4nB8v" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"kind": "corporate-disqualification",
+			"name": "MILLSTONE NOMINEES LIMITED",
+			"company_number": "08812345",
+			"disqualifications": [
+				{"disqualification_type": "undertaking", "disqualified_from": "2022-10-20", "disqualified_until": "2027-10-19", "undertaken_on": "2022-09-29"}
+			]
+		}`))
+	})
+
+	officer, err := client.GetCorporateDisqualifiedOfficer(context.Background(), "mS4nB8v")
+	if err != nil {
+		t.Fatalf("GetCorporateDisqualifiedOfficer() error: %v", err)
+	}
+	if officer.CompanyNumber != "08812345" {
+		t.Errorf("CompanyNumber = %q, want %q", officer.CompanyNumber, "08812345")
+	}
+	if len(officer.Disqualifications) != 1 || officer.Disqualifications[0].UndertakenOn != "2022-09-29" {
+		t.Errorf("Disqualifications = %+v", officer.Disqualifications)
+	}
+}
+
+func TestParseDisqualifiedOfficerLink(t *testing.T) {
+	tests := []struct {
+		link     string
+		kind, id string
+		ok       bool
+	}{
+		{"/disqualified-officers/natural/abc", "natural", "abc", true},
+		{"/disqualified-officers/corporate/xyz", "corporate", "xyz", true},
+		{"/disqualified-officers/other/abc", "", "", false},
+		{"/disqualified-officers/natural/", "", "", false},
+		{"/officers/abc/appointments", "", "", false},
+	}
+	for _, tt := range tests {
+		kind, id, ok := chapi.ParseDisqualifiedOfficerLink(tt.link)
+		if kind != tt.kind || id != tt.id || ok != tt.ok {
+			t.Errorf("ParseDisqualifiedOfficerLink(%q) = %q, %q, %v; want %q, %q, %v",
+				tt.link, kind, id, ok, tt.kind, tt.id, tt.ok)
+		}
+	}
+}
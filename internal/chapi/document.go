@@ -0,0 +1,193 @@
+package chapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const documentBaseURL = "https://document-api.company-information.service.gov.uk"
+
+// Content types served by the Document API.
+const (
+	ContentTypePDF   = "application/pdf"
+	ContentTypeXHTML = "application/xhtml+xml"
+)
+
+// DocumentClient wraps the Companies House Document API, which serves the
+// images and iXBRL behind filing history items.
+type DocumentClient struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+	limiter    *RateLimiter
+	opts       options
+}
+
+// NewDocumentClient creates a Document API client. It accepts the same
+// options as New; the timeout applies only until response headers arrive,
+// so large downloads are not cut off, and the cache and retry options are
+// ignored.
+func NewDocumentClient(apiKey string, opts ...Option) *DocumentClient {
+	o := newOptions(documentBaseURL, opts)
+	return &DocumentClient{
+		apiKey:     apiKey,
+		httpClient: o.buildStreamingHTTPClient(),
+		baseURL:    o.baseURL,
+		limiter:    o.limiter,
+		opts:       o,
+	}
+}
+
+// NewDocumentClientWithBaseURL creates a document client with a custom base
+// URL (for testing). Like NewWithBaseURL it gets its own rate limiter.
+func NewDocumentClientWithBaseURL(apiKey, base string, opts ...Option) *DocumentClient {
+	opts = append([]Option{WithBaseURL(base), WithRateLimiter(NewRateLimiter(defaultRateLimit, defaultRateWindow))}, opts...)
+	return NewDocumentClient(apiKey, opts...)
+}
+
+// DocumentMetadata describes a filed document and the formats it is
+// available in.
+type DocumentMetadata struct {
+	CompanyNumber       string                      `json:"company_number"`
+	Barcode             string                      `json:"barcode,omitempty"`
+	Category            string                      `json:"category,omitempty"`
+	SignificantDate     string                      `json:"significant_date,omitempty"`
+	SignificantDateType string                      `json:"significant_date_type,omitempty"`
+	Pages               int                         `json:"pages,omitempty"`
+	CreatedAt           string                      `json:"created_at,omitempty"`
+	Resources           map[string]DocumentResource `json:"resources,omitempty"`
+	Links               DocumentLinks               `json:"links"`
+}
+
+// DocumentResource is one format a document is available in.
+type DocumentResource struct {
+	ContentLength int64  `json:"content_length,omitempty"`
+	CreatedAt     string `json:"created_at,omitempty"`
+	UpdatedAt     string `json:"updated_at,omitempty"`
+}
+
+// DocumentLinks holds the metadata and content links for a document.
+type DocumentLinks struct {
+	Self     string `json:"self"`
+	Document string `json:"document,omitempty"`
+}
+
+// HasFormat reports whether the document is available as contentType. If
+// the metadata lists no resources it reports true and leaves the decision to
+// the server.
+func (m *DocumentMetadata) HasFormat(contentType string) bool {
+	if len(m.Resources) == 0 {
+		return true
+	}
+	_, ok := m.Resources[contentType]
+	return ok
+}
+
+// DocumentIDFromLink returns the document ID from a document_metadata link
+// such as https://document-api.company-information.service.gov.uk/document/{id},
+// or "" if link is not one.
+func DocumentIDFromLink(link string) string {
+	if u, err := url.Parse(link); err == nil {
+		link = u.Path
+	}
+	id, ok := strings.CutPrefix(link, "/document/")
+	if !ok {
+		return ""
+	}
+	id = strings.TrimSuffix(id, "/content")
+	if id == "" || strings.Contains(id, "/") {
+		return ""
+	}
+	return id
+}
+
+// GetDocumentMetadata retrieves the metadata for a document.
+func (c *DocumentClient) GetDocumentMetadata(ctx context.Context, documentID string) (*DocumentMetadata, error) {
+	resp, err := c.send(ctx, c.baseURL+"/document/"+documentID, "application/json", true)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var result DocumentMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	return &result, nil
+}
+
+// DownloadDocument writes the document's content in the requested format to
+// w and returns the number of bytes written. The Document API answers with a
+// redirect to short-lived storage; the redirect is followed without the API
+// key, which the storage host must not receive. A further redirect from the
+// storage host is an error.
+func (c *DocumentClient) DownloadDocument(ctx context.Context, documentID, contentType string, w io.Writer) (int64, error) {
+	resp, err := c.send(ctx, c.baseURL+"/document/"+documentID+"/content", contentType, true)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		resp.Body.Close()
+		loc, err := resp.Location()
+		if err != nil {
+			return 0, fmt.Errorf("document redirect: %w", err)
+		}
+		if resp, err = c.send(ctx, loc.String(), contentType, false); err != nil {
+			return 0, err
+		}
+		if resp.StatusCode >= 300 {
+			resp.Body.Close()
+			return 0, fmt.Errorf("document redirect: storage answered %s", resp.Status)
+		}
+	}
+	defer resp.Body.Close()
+
+	n, err := io.Copy(w, resp.Body)
+	if err != nil {
+		return n, fmt.Errorf("read document: %w", err)
+	}
+	return n, nil
+}
+
+// send performs a GET and returns the response for a 2xx or 3xx status.
+// Redirects are not followed. When authenticated is false the request
+// carries no API key and is not counted against the rate limit.
+func (c *DocumentClient) send(ctx context.Context, u, accept string, authenticated bool) (*http.Response, error) {
+	if authenticated {
+		if err := c.limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	if authenticated {
+		req.SetBasicAuth(c.apiKey, "")
+	}
+	c.opts.prepare(req)
+	req.Header.Set("Accept", accept)
+
+	hc := *c.httpClient
+	hc.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	if authenticated {
+		c.limiter.Observe(resp.Header)
+	}
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, newAPIError(resp.StatusCode, body)
+	}
+	return resp, nil
+}
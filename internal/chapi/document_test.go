@@ -0,0 +1,153 @@
+package chapi_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestGetDocumentMetadata_Success(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/document/tN2sQmR7" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if user, _, ok := r.BasicAuth(); !ok || user != "test-api-key" {
+			t.Errorf("BasicAuth user = %q, want %q", user, "test-api-key")
+		}
+		w.Write([]byte(`{
+			"company_number": "00445790",
+			"category": "accounts",
+			"pages": 42,
+			"resources": {
+				"application/pdf": {"content_length": 1024},
+				"application/xhtml+xml": {"content_length": 2048}
+			},
+			"links": {"self": "/document/tN2sQmR7", "document": "/document/tN2sQmR7/content"}
+		}`))
+	}))
+	defer srv.Close()
+
+	client := chapi.NewDocumentClientWithBaseURL("test-api-key", srv.URL)
+	meta, err := client.GetDocumentMetadata(context.Background(), "tN2sQmR7")
+	if err != nil {
+		t.Fatalf("GetDocumentMetadata() error: %v", err)
+	}
+	if meta.Pages != 42 {
+		t.Errorf("Pages = %d, want 42", meta.Pages)
+	}
+	if !meta.HasFormat(chapi.ContentTypeXHTML) {
+		t.Error("HasFormat(xhtml) = false, want true")
+	}
+	if meta.HasFormat("text/csv") {
+		t.Error("HasFormat(text/csv) = true, want false")
+	}
+}
+
+func TestDownloadDocument_FollowsRedirectWithoutAPIKey(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "" {
+			t.Errorf("storage request carried Authorization %q", auth)
+		}
+		if accept := r.Header.Get("Accept"); accept != chapi.ContentTypePDF {
+			t.Errorf("storage Accept = %q, want %q", accept, chapi.ContentTypePDF)
+		}
+		w.Write([]byte("%PDF-1.4 test"))
+	}))
+	defer storage.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/document/tN2sQmR7/content" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if accept := r.Header.Get("Accept"); accept != chapi.ContentTypePDF {
+			t.Errorf("Accept = %q, want %q", accept, chapi.ContentTypePDF)
+		}
+		http.Redirect(w, r, storage.URL+"/signed?sig=abc", http.StatusFound)
+	}))
+	defer api.Close()
+
+	client := chapi.NewDocumentClientWithBaseURL("test-api-key", api.URL)
+	var buf bytes.Buffer
+	n, err := client.DownloadDocument(context.Background(), "tN2sQmR7", chapi.ContentTypePDF, &buf)
+	if err != nil {
+		t.Fatalf("DownloadDocument() error: %v", err)
+	}
+	if got := buf.String(); got != "%PDF-1.4 test" || n != int64(len(got)) {
+		t.Errorf("DownloadDocument() = %d bytes %q", n, got)
+	}
+}
+
+func TestDownloadDocument_SecondRedirect(t *testing.T) {
+	storage := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer storage.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, storage.URL+"/signed?sig=abc", http.StatusFound)
+	}))
+	defer api.Close()
+
+	client := chapi.NewDocumentClientWithBaseURL("test-api-key", api.URL)
+	var buf bytes.Buffer
+	if _, err := client.DownloadDocument(context.Background(), "tN2sQmR7", chapi.ContentTypePDF, &buf); err == nil {
+		t.Fatal("DownloadDocument() error = nil, want an error for a second redirect")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("DownloadDocument() wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestDownloadDocument_TimeoutDoesNotCapBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("%PDF-1.4 "))
+		w.(http.Flusher).Flush()
+		time.Sleep(300 * time.Millisecond)
+		w.Write([]byte("rest"))
+	}))
+	defer srv.Close()
+
+	client := chapi.NewDocumentClientWithBaseURL("test-api-key", srv.URL, chapi.WithTimeout(100*time.Millisecond))
+	var buf bytes.Buffer
+	if _, err := client.DownloadDocument(context.Background(), "slow", chapi.ContentTypePDF, &buf); err != nil {
+		t.Fatalf("DownloadDocument() error: %v", err)
+	}
+	if got := buf.String(); got != "%PDF-1.4 rest" {
+		t.Errorf("DownloadDocument() = %q, want the whole body", got)
+	}
+}
+
+func TestDownloadDocument_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"error": "document-not-found", "type": "ch:service"}]}`))
+	}))
+	defer srv.Close()
+
+	client := chapi.NewDocumentClientWithBaseURL("test-api-key", srv.URL)
+	_, err := client.DownloadDocument(context.Background(), "missing", chapi.ContentTypePDF, &bytes.Buffer{})
+	if !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestDocumentIDFromLink(t *testing.T) {
+	tests := map[string]string{
+		"https://document-api.company-information.service.gov.uk/document/tN2sQmR7": "tN2sQmR7",
+		"/document/tN2sQmR7/content": "tN2sQmR7",
+		"/document/":                 "",
+		"/company/00445790":          "",
+		"":                           "",
+	}
+	for link, want := range tests {
+		if got := chapi.DocumentIDFromLink(link); got != want {
+			t.Errorf("DocumentIDFromLink(%q) = %q, want %q", link, got, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package chapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError.Is, so callers can branch with
+// errors.Is(err, chapi.ErrNotFound) without inspecting status codes.
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorised")
+	ErrRateLimited  = errors.New("rate limited")
+	ErrValidation   = errors.New("validation failed")
+	ErrServer       = errors.New("server error")
+)
+
+// ErrorDetail is one entry of the errors array in an API error response.
+type ErrorDetail struct {
+	Error        string            `json:"error"`
+	Type         string            `json:"type"`
+	Location     string            `json:"location,omitempty"`
+	LocationType string            `json:"location_type,omitempty"`
+	ErrorValues  map[string]string `json:"error_values,omitempty"`
+}
+
+// APIError represents an error response from the API.
+type APIError struct {
+	StatusCode int
+	Body       string
+	Errors     []ErrorDetail
+}
+
+// newAPIError builds an APIError, decoding the body when it is in either of
+// the two shapes the API uses: an errors array, or a single error object.
+func newAPIError(status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, Body: string(body)}
+
+	var decoded struct {
+		Errors []ErrorDetail `json:"errors"`
+		ErrorDetail
+	}
+	if json.Unmarshal(body, &decoded) == nil {
+		e.Errors = decoded.Errors
+		if len(e.Errors) == 0 && (decoded.Error != "" || decoded.Type != "") {
+			e.Errors = []ErrorDetail{decoded.ErrorDetail}
+		}
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	var msgs []string
+	for _, d := range e.Errors {
+		msg := d.Error
+		if msg == "" {
+			msg = d.Type
+		}
+		if d.Location != "" {
+			msg += " (" + d.Location + ")"
+		}
+		if msg != "" {
+			msgs = append(msgs, msg)
+		}
+	}
+	if len(msgs) > 0 {
+		return fmt.Sprintf("Companies House API error (HTTP %d): %s", e.StatusCode, strings.Join(msgs, "; "))
+	}
+	return fmt.Sprintf("Companies House API error (HTTP %d): %s", e.StatusCode, e.Body)
+}
+
+// Is reports whether the error falls into the category of target, one of the
+// sentinel errors declared above.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	case ErrValidation:
+		return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+	case ErrServer:
+		return e.StatusCode >= 500
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// UKEstablishment is a UK branch registered by an overseas company.
+type UKEstablishment struct {
+	CompanyName   string            `json:"company_name"`
+	CompanyNumber string            `json:"company_number"`
+	CompanyStatus string            `json:"company_status"`
+	Locality      string            `json:"locality,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+}
+
+// UKEstablishmentList holds the UK establishments of an overseas company.
+type UKEstablishmentList struct {
+	Kind  string            `json:"kind,omitempty"`
+	Items []UKEstablishment `json:"items"`
+	Links map[string]string `json:"links,omitempty"`
+}
+
+// ListUKEstablishments retrieves the UK establishments of an overseas
+// company (an FC or SF number).
+func (c *Client) ListUKEstablishments(ctx context.Context, companyNumber string) (*UKEstablishmentList, error) {
+	var result UKEstablishmentList
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/uk-establishments", companyNumber), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
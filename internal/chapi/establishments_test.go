@@ -0,0 +1,33 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestListUKEstablishments_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/FC031234/uk-establishments" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"kind": "related-companies",
+			"items": [{
+				"company_name": "NORDVIND ENERGI AS",
+				"company_number": "BR015678",
+				"company_status": "open",
+				"locality": "Aberdeen",
+				"links": {"company": "/company/BR015678"}
+			}]
+		}`))
+	})
+
+	result, err := client.ListUKEstablishments(context.Background(), "FC031234")
+	if err != nil {
+		t.Fatalf("ListUKEstablishments() error: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].CompanyNumber != "BR015678" || result.Items[0].Locality != "Aberdeen" {
+		t.Errorf("Items = %+v", result.Items)
+	}
+}
@@ -0,0 +1,46 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// ExemptionPeriod is a span during which an exemption applied. ExemptTo is
+// empty while the exemption is still in force.
+type ExemptionPeriod struct {
+	ExemptFrom string `json:"exempt_from"`
+	ExemptTo   string `json:"exempt_to,omitempty"`
+}
+
+// Exemption is one PSC disclosure exemption and the periods it applied for.
+type Exemption struct {
+	ExemptionType string            `json:"exemption_type"`
+	Items         []ExemptionPeriod `json:"items"`
+}
+
+// Active reports whether any period of the exemption is still open.
+func (e Exemption) Active() bool {
+	for _, p := range e.Items {
+		if p.ExemptTo == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CompanyExemptions holds a company's exemptions from keeping a PSC register,
+// keyed by exemption type (e.g. psc_exempt_as_trading_on_regulated_market).
+type CompanyExemptions struct {
+	Kind       string               `json:"kind,omitempty"`
+	Exemptions map[string]Exemption `json:"exemptions"`
+	Links      map[string]string    `json:"links,omitempty"`
+}
+
+// GetExemptions retrieves a company's PSC disclosure exemptions.
+func (c *Client) GetExemptions(ctx context.Context, companyNumber string) (*CompanyExemptions, error) {
+	var result CompanyExemptions
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/exemptions", companyNumber), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,39 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestGetExemptions_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/00445790/exemptions" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"kind": "exemptions",
+			"exemptions": {
+				"psc_exempt_as_trading_on_regulated_market": {
+					"exemption_type": "psc-exempt-as-trading-on-regulated-market",
+					"items": [{"exempt_from": "2016-06-30"}]
+				},
+				"psc_exempt_as_shares_admitted_on_market": {
+					"exemption_type": "psc-exempt-as-shares-admitted-on-market",
+					"items": [{"exempt_from": "2016-06-30", "exempt_to": "2018-01-01"}]
+				}
+			}
+		}`))
+	})
+
+	result, err := client.GetExemptions(context.Background(), "00445790")
+	if err != nil {
+		t.Fatalf("GetExemptions() error: %v", err)
+	}
+	if !result.Exemptions["psc_exempt_as_trading_on_regulated_market"].Active() {
+		t.Error("open-ended exemption reported inactive")
+	}
+	if result.Exemptions["psc_exempt_as_shares_admitted_on_market"].Active() {
+		t.Error("ended exemption reported active")
+	}
+}
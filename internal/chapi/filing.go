@@ -53,3 +53,26 @@ func (c *Client) GetFilingHistoryItem(ctx context.Context, companyNumber, transa
 	}
 	return &item, nil
 }
+
+// ListAllFilingHistory fetches every page of filing history for a company. If
+// limit is greater than zero, at most limit filings are returned.
+func (c *Client) ListAllFilingHistory(ctx context.Context, companyNumber string, category string, limit int) (*FilingHistoryList, error) {
+	var result *FilingHistoryList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]FilingHistoryItem, int, error) {
+		page, err := c.ListFilingHistory(ctx, companyNumber, category, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalCount, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
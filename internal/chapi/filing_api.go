@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"time"
 )
 
 const filingBaseURL = "https://api.company-information.service.gov.uk"
@@ -17,24 +16,23 @@ type FilingClient struct {
 	accessToken string
 	httpClient  *http.Client
 	baseURL     string
+	opts        options
 }
 
 // NewFilingClient creates a new filing API client with an OAuth2 access token.
-func NewFilingClient(accessToken string) *FilingClient {
+func NewFilingClient(accessToken string, opts ...Option) *FilingClient {
+	o := newOptions(filingBaseURL, opts)
 	return &FilingClient{
 		accessToken: accessToken,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
-		baseURL:     filingBaseURL,
+		httpClient:  o.buildHTTPClient(),
+		baseURL:     o.baseURL,
+		opts:        o,
 	}
 }
 
 // NewFilingClientWithBaseURL creates a filing client with a custom base URL (for testing).
-func NewFilingClientWithBaseURL(accessToken, base string) *FilingClient {
-	return &FilingClient{
-		accessToken: accessToken,
-		httpClient:  &http.Client{Timeout: 30 * time.Second},
-		baseURL:     base,
-	}
+func NewFilingClientWithBaseURL(accessToken, base string, opts ...Option) *FilingClient {
+	return NewFilingClient(accessToken, append([]Option{WithBaseURL(base)}, opts...)...)
 }
 
 // Transaction represents a filing transaction.
@@ -152,6 +150,7 @@ func (c *FilingClient) doRequest(ctx context.Context, method, path string, body
 		return fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+	c.opts.prepare(req)
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
@@ -168,10 +167,7 @@ func (c *FilingClient) doRequest(ctx context.Context, method, path string, body
 	}
 
 	if resp.StatusCode >= 400 {
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Body:       string(respBody),
-		}
+		return newAPIError(resp.StatusCode, respBody)
 	}
 
 	if out != nil && len(respBody) > 0 {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strings"
 )
 
 // Officer represents a company officer.
@@ -16,7 +17,77 @@ type Officer struct {
 	Occupation  string `json:"occupation,omitempty"`
 	CountryOfResidence string `json:"country_of_residence,omitempty"`
 	Address     RegisteredOffice `json:"address"`
-	Links       map[string]string `json:"links,omitempty"`
+	DateOfBirth *DateOfBirth `json:"date_of_birth,omitempty"`
+	IsPre1992Appointment bool `json:"is_pre_1992_appointment,omitempty"`
+	Identification *OfficerIdentification `json:"identification,omitempty"`
+	FormerNames []FormerName `json:"former_names,omitempty"`
+	Responsibilities string `json:"responsibilities,omitempty"`
+	ContactDetails *ContactDetails `json:"contact_details,omitempty"`
+	PrincipalOfficeAddress *RegisteredOffice `json:"principal_office_address,omitempty"`
+	Links       OfficerLinks `json:"links,omitzero"`
+}
+
+// OfficerIdentification describes how a corporate officer is registered.
+type OfficerIdentification struct {
+	IdentificationType string `json:"identification_type,omitempty"`
+	LegalAuthority     string `json:"legal_authority,omitempty"`
+	LegalForm          string `json:"legal_form,omitempty"`
+	PlaceRegistered    string `json:"place_registered,omitempty"`
+	RegistrationNumber string `json:"registration_number,omitempty"`
+}
+
+// FormerName is a name an officer was previously known by.
+type FormerName struct {
+	Forenames string `json:"forenames,omitempty"`
+	Surname   string `json:"surname,omitempty"`
+}
+
+// ContactDetails names the contact given for some corporate appointments.
+type ContactDetails struct {
+	ContactName string `json:"contact_name,omitempty"`
+}
+
+// AppointmentID returns the ID of this appointment, taken from the self link
+// on company officer lists. It returns "" if the link is missing.
+func (o Officer) AppointmentID() string {
+	_, id, ok := strings.Cut(o.Links.Self, "/appointments/")
+	if !ok || !strings.HasPrefix(o.Links.Self, "/company/") {
+		return ""
+	}
+	return id
+}
+
+// OfficerLinks holds the links attached to an officer record.
+type OfficerLinks struct {
+	Self    string          `json:"self,omitempty"`
+	Officer OfficerLinkRefs `json:"officer,omitzero"`
+}
+
+// OfficerLinkRefs holds links to resources about the officer as a person,
+// rather than about one appointment.
+type OfficerLinkRefs struct {
+	Appointments string `json:"appointments,omitempty"`
+}
+
+// OfficerID returns the officer's ID, taken from the officer.appointments link
+// on company officer lists or the self link on officer search results. It
+// returns "" if neither link is present.
+func (o Officer) OfficerID() string {
+	for _, link := range []string{o.Links.Officer.Appointments, o.Links.Self} {
+		if id, ok := strings.CutPrefix(link, "/officers/"); ok {
+			id, _, _ = strings.Cut(id, "/")
+			return id
+		}
+	}
+	return ""
+}
+
+// DateOfBirth is the partial date of birth the API discloses for officers
+// and PSCs. Day is only present in some filing contexts.
+type DateOfBirth struct {
+	Day   int `json:"day,omitempty"`
+	Month int `json:"month"`
+	Year  int `json:"year"`
 }
 
 // OfficerList holds a list of officers.
@@ -46,6 +117,15 @@ func (c *Client) ListOfficers(ctx context.Context, companyNumber string, itemsPe
 	return &result, nil
 }
 
+// GetOfficerAppointment retrieves a single officer appointment at a company.
+func (c *Client) GetOfficerAppointment(ctx context.Context, companyNumber, appointmentID string) (*Officer, error) {
+	var result Officer
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/appointments/%s", companyNumber, appointmentID), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // OfficerSearchResult holds officer search results.
 type OfficerSearchResult struct {
 	TotalResults int       `json:"total_results"`
@@ -72,3 +152,49 @@ func (c *Client) SearchOfficers(ctx context.Context, query string, itemsPerPage,
 	}
 	return &result, nil
 }
+
+// ListAllOfficers fetches every page of officers for a company. If limit is
+// greater than zero, at most limit officers are returned.
+func (c *Client) ListAllOfficers(ctx context.Context, companyNumber string, limit int) (*OfficerList, error) {
+	var result *OfficerList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]Officer, int, error) {
+		page, err := c.ListOfficers(ctx, companyNumber, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
+
+// SearchAllOfficers fetches every page of officer search results. If limit is
+// greater than zero, at most limit results are returned.
+func (c *Client) SearchAllOfficers(ctx context.Context, query string, limit int) (*OfficerSearchResult, error) {
+	var result *OfficerSearchResult
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]Officer, int, error) {
+		page, err := c.SearchOfficers(ctx, query, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
@@ -77,3 +77,42 @@ func TestSearchOfficers_Success(t *testing.T) {
 		t.Errorf("TotalResults = %d, want %d", result.TotalResults, 5)
 	}
 }
+
+func TestGetOfficerAppointment_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/13876501/appointments/zQ5xW8c" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"name": "PARKROW SECRETARIES LIMITED",
+			"officer_role": "corporate-secretary",
+			"appointed_on": "2022-01-20",
+			"address": {"address_line_1": "1 Wellington Place", "locality": "Leeds"},
+			"identification": {
+				"identification_type": "uk-limited-company",
+				"place_registered": "Companies House",
+				"registration_number": "09123456"
+			},
+			"former_names": [{"forenames": "", "surname": "PARKROW NOMINEES LIMITED"}],
+			"responsibilities": "Maintains the statutory registers.",
+			"links": {"self": "/company/13876501/appointments/zQ5xW8c"}
+		}`))
+	})
+
+	o, err := client.GetOfficerAppointment(context.Background(), "13876501", "zQ5xW8c")
+	if err != nil {
+		t.Fatalf("GetOfficerAppointment() error: %v", err)
+	}
+	if o.Identification == nil || o.Identification.RegistrationNumber != "09123456" {
+		t.Errorf("Identification = %+v, want registration number 09123456", o.Identification)
+	}
+	if len(o.FormerNames) != 1 || o.FormerNames[0].Surname != "PARKROW NOMINEES LIMITED" {
+		t.Errorf("FormerNames = %+v", o.FormerNames)
+	}
+	if o.Responsibilities == "" {
+		t.Error("Responsibilities is empty")
+	}
+	if got := o.AppointmentID(); got != "zQ5xW8c" {
+		t.Errorf("AppointmentID() = %q, want %q", got, "zQ5xW8c")
+	}
+}
@@ -0,0 +1,171 @@
+package chapi
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	defaultTimeout   = 30 * time.Second
+	defaultUserAgent = "ch"
+)
+
+// RetryPolicy controls how often a failed request is attempted and how long
+// to wait between network-level failures. Rate-limited responses wait for the
+// quota to reset instead of using Backoff.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     func(attempt int) time.Duration
+}
+
+// DefaultRetryPolicy makes three attempts with a linear 500ms backoff.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		Backoff: func(attempt int) time.Duration {
+			return time.Duration(attempt+1) * 500 * time.Millisecond
+		},
+	}
+}
+
+// RequestHook is called with every outgoing request just before it is sent.
+// Hooks may add headers or record the request.
+type RequestHook func(req *http.Request)
+
+// options collects the settings applied by Option values.
+type options struct {
+	baseURL    string
+	httpClient *http.Client
+	transport  http.RoundTripper
+	timeout    time.Duration
+	retry      RetryPolicy
+	userAgent  string
+	hooks      []RequestHook
+	limiter    *RateLimiter
+	cache      CacheOptions
+}
+
+// Option configures a Client, FilingClient or DocumentClient. FilingClient
+// ignores the retry, rate limit and cache options; DocumentClient ignores the
+// retry and cache options.
+type Option func(*options)
+
+// WithBaseURL points the client at a different API host.
+func WithBaseURL(base string) Option {
+	return func(o *options) { o.baseURL = base }
+}
+
+// WithHTTPClient sends requests through hc. The client is copied, so
+// WithTimeout and WithTransport never modify the caller's value.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(o *options) { o.httpClient = hc }
+}
+
+// WithTransport sends requests through rt.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(o *options) { o.transport = rt }
+}
+
+// WithTimeout sets the overall timeout for each HTTP request. The default is
+// 30 seconds unless WithHTTPClient supplies a client with its own timeout.
+// Document and stream clients apply it only until response headers arrive.
+func WithTimeout(d time.Duration) Option {
+	return func(o *options) { o.timeout = d }
+}
+
+// WithRetryPolicy replaces the default retry policy.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(o *options) { o.retry = p }
+}
+
+// WithRetries sets the maximum number of attempts per request, keeping the
+// default backoff.
+func WithRetries(n int) Option {
+	return func(o *options) { o.retry.MaxAttempts = n }
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(ua string) Option {
+	return func(o *options) { o.userAgent = ua }
+}
+
+// WithRequestHook adds a hook run on every outgoing request. Hooks run in the
+// order they were added.
+func WithRequestHook(h RequestHook) Option {
+	return func(o *options) { o.hooks = append(o.hooks, h) }
+}
+
+// WithRateLimiter paces requests with l instead of the limiter shared by all
+// clients in the process.
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(o *options) { o.limiter = l }
+}
+
+// WithCache enables the on-disk response cache.
+func WithCache(c CacheOptions) Option {
+	return func(o *options) { o.cache = c }
+}
+
+func newOptions(base string, opts []Option) options {
+	o := options{
+		baseURL:   base,
+		retry:     DefaultRetryPolicy(),
+		userAgent: defaultUserAgent,
+		limiter:   sharedLimiter,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.retry.MaxAttempts < 1 {
+		o.retry.MaxAttempts = 1
+	}
+	if o.retry.Backoff == nil {
+		o.retry.Backoff = DefaultRetryPolicy().Backoff
+	}
+	return o
+}
+
+// buildHTTPClient returns the http.Client described by the options.
+func (o options) buildHTTPClient() *http.Client {
+	hc := &http.Client{Timeout: defaultTimeout}
+	if o.httpClient != nil {
+		copied := *o.httpClient
+		hc = &copied
+	}
+	if o.transport != nil {
+		hc.Transport = o.transport
+	}
+	if o.timeout > 0 {
+		hc.Timeout = o.timeout
+	}
+	return hc
+}
+
+// buildStreamingHTTPClient is like buildHTTPClient but applies the timeout
+// only until response headers arrive, so long response bodies are not cut
+// off. The timeout is dropped for transports other than *http.Transport.
+func (o options) buildStreamingHTTPClient() *http.Client {
+	hc := o.buildHTTPClient()
+	timeout := hc.Timeout
+	hc.Timeout = 0
+	if timeout > 0 {
+		t, ok := hc.Transport.(*http.Transport)
+		if hc.Transport == nil {
+			t, ok = http.DefaultTransport.(*http.Transport), true
+		}
+		if ok {
+			t = t.Clone()
+			t.ResponseHeaderTimeout = timeout
+			hc.Transport = t
+		}
+	}
+	return hc
+}
+
+// prepare applies the user agent and hooks to an outgoing request.
+func (o options) prepare(req *http.Request) {
+	req.Header.Set("User-Agent", o.userAgent)
+	for _, h := range o.hooks {
+		h(req)
+	}
+}
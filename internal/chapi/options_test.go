@@ -0,0 +1,106 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestNew_UserAgentAndHooks(t *testing.T) {
+	var gotUA, gotHeader string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		gotHeader = r.Header.Get("X-Request-Source")
+		w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := chapi.NewWithBaseURL("test-api-key", srv.URL,
+		chapi.WithUserAgent("compliance-bot/1.0"),
+		chapi.WithRequestHook(func(req *http.Request) {
+			req.Header.Set("X-Request-Source", "batch")
+		}),
+	)
+	if _, err := client.GetCompany(context.Background(), "00445790"); err != nil {
+		t.Fatalf("GetCompany() error: %v", err)
+	}
+	if gotUA != "compliance-bot/1.0" {
+		t.Errorf("User-Agent = %q, want %q", gotUA, "compliance-bot/1.0")
+	}
+	if gotHeader != "batch" {
+		t.Errorf("X-Request-Source = %q, want %q", gotHeader, "batch")
+	}
+}
+
+func TestNew_Transport(t *testing.T) {
+	var gotURL string
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotURL = req.URL.String()
+		rec := httptest.NewRecorder()
+		rec.Write([]byte(`{"company_name": "TESCO PLC"}`))
+		return rec.Result(), nil
+	})
+
+	client := chapi.New("test-api-key",
+		chapi.WithBaseURL("https://ch.example.test"),
+		chapi.WithTransport(rt),
+		chapi.WithRateLimiter(chapi.NewRateLimiter(600, 5*time.Minute)),
+	)
+	profile, err := client.GetCompany(context.Background(), "00445790")
+	if err != nil {
+		t.Fatalf("GetCompany() error: %v", err)
+	}
+	if gotURL != "https://ch.example.test/company/00445790" {
+		t.Errorf("URL = %q", gotURL)
+	}
+	if profile.CompanyName != "TESCO PLC" {
+		t.Errorf("CompanyName = %q, want %q", profile.CompanyName, "TESCO PLC")
+	}
+}
+
+func TestNew_RetryPolicy(t *testing.T) {
+	attempts := 0
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		attempts++
+		return nil, context.DeadlineExceeded
+	})
+
+	client := chapi.NewWithBaseURL("test-api-key", "https://ch.example.test",
+		chapi.WithTransport(rt),
+		chapi.WithRetryPolicy(chapi.RetryPolicy{
+			MaxAttempts: 5,
+			Backoff:     func(int) time.Duration { return 0 },
+		}),
+	)
+	if _, err := client.GetCompany(context.Background(), "00445790"); err == nil {
+		t.Fatal("expected error when every attempt fails")
+	}
+	if attempts != 5 {
+		t.Errorf("attempts = %d, want 5", attempts)
+	}
+}
+
+func TestNewFilingClient_Options(t *testing.T) {
+	var gotUA string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotUA = r.Header.Get("User-Agent")
+		w.Write([]byte(`{"id": "txn-1"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := chapi.NewFilingClient("token", chapi.WithBaseURL(srv.URL), chapi.WithUserAgent("ch/test"))
+	if _, err := client.GetTransaction(context.Background(), "txn-1"); err != nil {
+		t.Fatalf("GetTransaction() error: %v", err)
+	}
+	if gotUA != "ch/test" {
+		t.Errorf("User-Agent = %q, want %q", gotUA, "ch/test")
+	}
+}
@@ -0,0 +1,250 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CompanyTypeOverseasEntity is the company type of an entity on the Register
+// of Overseas Entities. Their numbers start with OE.
+const CompanyTypeOverseasEntity = "registered-overseas-entity"
+
+// OriginatingRegistry is the registry an overseas entity is incorporated in.
+type OriginatingRegistry struct {
+	Name    string `json:"name,omitempty"`
+	Country string `json:"country,omitempty"`
+}
+
+// ForeignCompanyDetails describes where and how an overseas entity or
+// overseas company is incorporated.
+type ForeignCompanyDetails struct {
+	OriginatingRegistry *OriginatingRegistry `json:"originating_registry,omitempty"`
+	RegistrationNumber  string               `json:"registration_number,omitempty"`
+	LegalForm           string               `json:"legal_form,omitempty"`
+	GovernedBy          string               `json:"governed_by,omitempty"`
+	BusinessActivity    string               `json:"business_activity,omitempty"`
+}
+
+// OverseasEntity is the profile of an entity on the Register of Overseas
+// Entities: a company profile plus the incorporation details and service
+// address the register records. The registered office is the entity's
+// principal or registered office abroad.
+type OverseasEntity struct {
+	CompanyProfile
+	ForeignCompanyDetails           *ForeignCompanyDetails `json:"foreign_company_details,omitempty"`
+	ServiceAddress                  *RegisteredOffice      `json:"service_address,omitempty"`
+	SuperSecureManagingOfficerCount int                    `json:"super_secure_managing_officer_count,omitempty"`
+}
+
+// IsOverseasEntity reports whether the profile is on the Register of
+// Overseas Entities.
+func (e OverseasEntity) IsOverseasEntity() bool {
+	return e.Type == CompanyTypeOverseasEntity
+}
+
+// GetOverseasEntity retrieves an overseas entity's profile.
+func (c *Client) GetOverseasEntity(ctx context.Context, entityNumber string) (*OverseasEntity, error) {
+	var entity OverseasEntity
+	if err := c.get(ctx, fmt.Sprintf("/company/%s", entityNumber), nil, &entity); err != nil {
+		return nil, err
+	}
+	return &entity, nil
+}
+
+// Beneficial owner categories returned by BeneficialOwner.Category.
+const (
+	BeneficialOwnerIndividual  = "individual"
+	BeneficialOwnerCorporate   = "corporate"
+	BeneficialOwnerGovernment  = "government"
+	BeneficialOwnerSuperSecure = "super-secure"
+)
+
+// BeneficialOwner is a registrable beneficial owner of an overseas entity.
+// The API serves them from the entity's PSC list with the *-beneficial-owner
+// kinds; fields not relevant to a kind are left empty.
+type BeneficialOwner struct {
+	Kind                   string             `json:"kind"`
+	Name                   string             `json:"name,omitempty"`
+	NameElements           *NameElements      `json:"name_elements,omitempty"`
+	Description            string             `json:"description,omitempty"`
+	DateOfBirth            *DateOfBirth       `json:"date_of_birth,omitempty"`
+	Nationality            string             `json:"nationality,omitempty"`
+	CountryOfResidence     string             `json:"country_of_residence,omitempty"`
+	Address                *RegisteredOffice  `json:"address,omitempty"`
+	PrincipalOfficeAddress *RegisteredOffice  `json:"principal_office_address,omitempty"`
+	Identification         *PSCIdentification `json:"identification,omitempty"`
+	NaturesOfControl       []string           `json:"natures_of_control,omitempty"`
+	NotifiedOn             string             `json:"notified_on,omitempty"`
+	CeasedOn               string             `json:"ceased_on,omitempty"`
+	Ceased                 bool               `json:"ceased,omitempty"`
+	IsSanctioned           bool               `json:"is_sanctioned"`
+	Links                  map[string]string  `json:"links,omitempty"`
+}
+
+// Category returns which kind of beneficial owner this is: individual,
+// corporate, government (a government or public authority) or
+// super-secure. It is empty for kinds that are not beneficial owners.
+func (b BeneficialOwner) Category() string {
+	switch b.Kind {
+	case PSCKindIndividualBeneficialOwner:
+		return BeneficialOwnerIndividual
+	case PSCKindCorporateEntityBeneficialOwner:
+		return BeneficialOwnerCorporate
+	case PSCKindLegalPersonBeneficialOwner:
+		return BeneficialOwnerGovernment
+	case PSCKindSuperSecureBeneficialOwner:
+		return BeneficialOwnerSuperSecure
+	}
+	return ""
+}
+
+// ViaTrust reports whether any of the owner's control is held as a trustee,
+// which the register records with natures of control ending in
+// "as-trust-registered-overseas-entity".
+func (b BeneficialOwner) ViaTrust() bool {
+	for _, n := range b.NaturesOfControl {
+		if strings.Contains(n, "as-trust") {
+			return true
+		}
+	}
+	return false
+}
+
+// Active reports whether the owner has not ceased.
+func (b BeneficialOwner) Active() bool {
+	return b.CeasedOn == "" && !b.Ceased
+}
+
+// BeneficialOwnerList holds the beneficial owners of an overseas entity.
+type BeneficialOwnerList struct {
+	TotalResults int               `json:"total_results"`
+	ActiveCount  int               `json:"active_count"`
+	CeasedCount  int               `json:"ceased_count"`
+	Items        []BeneficialOwner `json:"items"`
+	StartIndex   int               `json:"start_index"`
+	ItemsPerPage int               `json:"items_per_page"`
+}
+
+// ListBeneficialOwners lists the registrable beneficial owners of an
+// overseas entity.
+func (c *Client) ListBeneficialOwners(ctx context.Context, entityNumber string, itemsPerPage, startIndex int) (*BeneficialOwnerList, error) {
+	params := url.Values{}
+	if itemsPerPage > 0 {
+		params.Set("items_per_page", fmt.Sprintf("%d", itemsPerPage))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", fmt.Sprintf("%d", startIndex))
+	}
+
+	var result BeneficialOwnerList
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/persons-with-significant-control", entityNumber), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAllBeneficialOwners fetches every page of beneficial owners. If limit
+// is greater than zero, at most limit owners are returned.
+func (c *Client) ListAllBeneficialOwners(ctx context.Context, entityNumber string, limit int) (*BeneficialOwnerList, error) {
+	var result *BeneficialOwnerList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]BeneficialOwner, int, error) {
+		page, err := c.ListBeneficialOwners(ctx, entityNumber, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
+
+// Managing officer roles.
+const (
+	OfficerRoleManagingOfficer          = "managing-officer"
+	OfficerRoleCorporateManagingOfficer = "corporate-managing-officer"
+)
+
+// ManagingOfficer is a managing officer of an overseas entity. Individual
+// officers carry a date of birth, nationality and occupation; corporate ones
+// an identification, principal office and contact.
+type ManagingOfficer struct {
+	Name                   string                 `json:"name"`
+	OfficerRole            string                 `json:"officer_role"`
+	AppointedOn            string                 `json:"appointed_on,omitempty"`
+	ResignedOn             string                 `json:"resigned_on,omitempty"`
+	Nationality            string                 `json:"nationality,omitempty"`
+	Occupation             string                 `json:"occupation,omitempty"`
+	CountryOfResidence     string                 `json:"country_of_residence,omitempty"`
+	DateOfBirth            *DateOfBirth           `json:"date_of_birth,omitempty"`
+	Address                RegisteredOffice       `json:"address"`
+	PrincipalOfficeAddress *RegisteredOffice      `json:"principal_office_address,omitempty"`
+	Identification         *OfficerIdentification `json:"identification,omitempty"`
+	ContactDetails         *ContactDetails        `json:"contact_details,omitempty"`
+	Responsibilities       string                 `json:"responsibilities,omitempty"`
+	Links                  OfficerLinks           `json:"links,omitzero"`
+}
+
+// IsCorporate reports whether the officer is a corporate managing officer.
+func (o ManagingOfficer) IsCorporate() bool {
+	return o.OfficerRole == OfficerRoleCorporateManagingOfficer
+}
+
+// ManagingOfficerList holds the managing officers of an overseas entity.
+type ManagingOfficerList struct {
+	TotalResults  int               `json:"total_results"`
+	ActiveCount   int               `json:"active_count"`
+	ResignedCount int               `json:"resigned_count"`
+	Items         []ManagingOfficer `json:"items"`
+	StartIndex    int               `json:"start_index"`
+	ItemsPerPage  int               `json:"items_per_page"`
+}
+
+// ListManagingOfficers lists the managing officers of an overseas entity.
+func (c *Client) ListManagingOfficers(ctx context.Context, entityNumber string, itemsPerPage, startIndex int) (*ManagingOfficerList, error) {
+	params := url.Values{}
+	if itemsPerPage > 0 {
+		params.Set("items_per_page", fmt.Sprintf("%d", itemsPerPage))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", fmt.Sprintf("%d", startIndex))
+	}
+
+	var result ManagingOfficerList
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/officers", entityNumber), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAllManagingOfficers fetches every page of managing officers. If limit
+// is greater than zero, at most limit officers are returned.
+func (c *Client) ListAllManagingOfficers(ctx context.Context, entityNumber string, limit int) (*ManagingOfficerList, error) {
+	var result *ManagingOfficerList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]ManagingOfficer, int, error) {
+		page, err := c.ListManagingOfficers(ctx, entityNumber, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
@@ -0,0 +1,122 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestGetOverseasEntity_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/OE012345" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"company_name": "HARBOURVIEW ESTATES LIMITED",
+			"company_number": "OE012345",
+			"company_status": "registered",
+			"type": "registered-overseas-entity",
+			"foreign_company_details": {
+				"originating_registry": {"name": "Registry of Corporate Affairs", "country": "Virgin Islands, British"},
+				"registration_number": "2091456",
+				"legal_form": "BVI business company"
+			},
+			"service_address": {"address_line_1": "20 Fenchurch Street", "locality": "London"}
+		}`))
+	})
+
+	entity, err := client.GetOverseasEntity(context.Background(), "OE012345")
+	if err != nil {
+		t.Fatalf("GetOverseasEntity() error: %v", err)
+	}
+	if !entity.IsOverseasEntity() || entity.CompanyStatus != "registered" {
+		t.Errorf("entity = %+v, want a registered overseas entity", entity.CompanyProfile)
+	}
+	if d := entity.ForeignCompanyDetails; d == nil || d.RegistrationNumber != "2091456" || d.OriginatingRegistry.Country != "Virgin Islands, British" {
+		t.Errorf("ForeignCompanyDetails = %+v", d)
+	}
+	if entity.ServiceAddress == nil || entity.ServiceAddress.Locality != "London" {
+		t.Errorf("ServiceAddress = %+v", entity.ServiceAddress)
+	}
+}
+
+func TestListBeneficialOwners_Categories(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/OE012345/persons-with-significant-control" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"total_results": 4,
+			"active_count": 3,
+			"ceased_count": 1,
+			"items": [
+				{"kind": "individual-beneficial-owner", "name": "Ms Elena MARSH", "is_sanctioned": true,
+				 "natures_of_control": ["ownership-of-shares-more-than-25-percent-registered-overseas-entity"]},
+				{"kind": "corporate-entity-beneficial-owner", "name": "MERIDIAN TRUST SERVICES LIMITED",
+				 "natures_of_control": ["ownership-of-shares-more-than-25-percent-as-trust-registered-overseas-entity"],
+				 "principal_office_address": {"locality": "Hamilton"}},
+				{"kind": "legal-person-beneficial-owner", "name": "NORLAND PUBLIC INVESTMENT AUTHORITY", "ceased_on": "2024-02-14"},
+				{"kind": "super-secure-beneficial-owner", "description": "super-secure-beneficial-owner", "ceased": false}
+			]
+		}`))
+	})
+
+	result, err := client.ListAllBeneficialOwners(context.Background(), "OE012345", 0)
+	if err != nil {
+		t.Fatalf("ListAllBeneficialOwners() error: %v", err)
+	}
+	want := []string{
+		chapi.BeneficialOwnerIndividual,
+		chapi.BeneficialOwnerCorporate,
+		chapi.BeneficialOwnerGovernment,
+		chapi.BeneficialOwnerSuperSecure,
+	}
+	if len(result.Items) != len(want) {
+		t.Fatalf("Items = %d, want %d", len(result.Items), len(want))
+	}
+	for i, b := range result.Items {
+		if b.Category() != want[i] {
+			t.Errorf("Items[%d].Category() = %q, want %q", i, b.Category(), want[i])
+		}
+	}
+	if !result.Items[0].IsSanctioned || result.Items[0].ViaTrust() {
+		t.Errorf("individual owner = %+v, want sanctioned and not via a trust", result.Items[0])
+	}
+	if !result.Items[1].ViaTrust() || result.Items[1].PrincipalOfficeAddress == nil {
+		t.Errorf("corporate owner = %+v, want held via a trust with a principal office", result.Items[1])
+	}
+	if result.Items[2].Active() || !result.Items[3].Active() {
+		t.Error("Active() disagrees with ceased_on/ceased")
+	}
+}
+
+func TestListManagingOfficers_Corporate(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/OE012345/officers" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"total_results": 1,
+			"active_count": 1,
+			"items": [{
+				"name": "MERIDIAN CORPORATE SERVICES LIMITED",
+				"officer_role": "corporate-managing-officer",
+				"appointed_on": "2022-12-01",
+				"contact_details": {"contact_name": "David Osei"},
+				"responsibilities": "Registered agent services",
+				"identification": {"registration_number": "61207"}
+			}]
+		}`))
+	})
+
+	result, err := client.ListManagingOfficers(context.Background(), "OE012345", 0, 0)
+	if err != nil {
+		t.Fatalf("ListManagingOfficers() error: %v", err)
+	}
+	o := result.Items[0]
+	if !o.IsCorporate() || o.ContactDetails == nil || o.ContactDetails.ContactName != "David Osei" || o.Responsibilities == "" {
+		t.Errorf("officer = %+v", o)
+	}
+}
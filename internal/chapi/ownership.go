@@ -0,0 +1,261 @@
+package chapi
+
+import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// OwnershipBand is a range of shareholding, in percent, as disclosed by a
+// PSC's natures of control. Bands are nominally exclusive at the bottom
+// ("more than 25%") but are treated as closed ranges here.
+type OwnershipBand struct {
+	Min float64 `json:"min"`
+	Max float64 `json:"max"`
+}
+
+// Of scales the band by a parent's band: the effective holding of an owner
+// that holds b of a company which in turn holds parent of the one below.
+func (b OwnershipBand) Of(parent OwnershipBand) OwnershipBand {
+	return OwnershipBand{Min: b.Min * parent.Min / 100, Max: b.Max * parent.Max / 100}
+}
+
+// String renders the band as e.g. "37.5-75%".
+func (b OwnershipBand) String() string {
+	return formatPercent(b.Min) + "-" + formatPercent(b.Max) + "%"
+}
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+}
+
+var ownershipNature = regexp.MustCompile(`^(?:ownership-of-shares|right-to-share-surplus-assets)-(?:(\d+)-to-(\d+)|more-than-(\d+))-percent`)
+
+// ShareOwnership returns the shareholding band disclosed by the first
+// ownership nature of control (shares, or surplus assets for LLPs), whether
+// held directly, as a trustee or as a firm member. It reports false when
+// the PSC's control is held only through voting rights, appointment rights
+// or significant influence.
+func ShareOwnership(naturesOfControl []string) (OwnershipBand, bool) {
+	for _, n := range naturesOfControl {
+		m := ownershipNature.FindStringSubmatch(n)
+		if m == nil {
+			continue
+		}
+		if m[3] != "" {
+			lower, _ := strconv.ParseFloat(m[3], 64)
+			return OwnershipBand{Min: lower, Max: 100}, true
+		}
+		lower, _ := strconv.ParseFloat(m[1], 64)
+		upper, _ := strconv.ParseFloat(m[2], 64)
+		return OwnershipBand{Min: lower, Max: upper}, true
+	}
+	return OwnershipBand{}, false
+}
+
+var ukCountries = map[string]bool{
+	"england":           true,
+	"wales":             true,
+	"england and wales": true,
+	"england & wales":   true,
+	"scotland":          true,
+	"northern ireland":  true,
+	"united kingdom":    true,
+	"uk":                true,
+	"great britain":     true,
+	"england-wales":     true,
+}
+
+// UKCompanyNumber returns the Companies House number of a corporate PSC
+// whose identification says it is registered in the UK, padded to the
+// usual eight characters (e.g. 1234567 becomes 01234567, SC12345 becomes
+// SC012345). It reports false for foreign or unidentified entities.
+func UKCompanyNumber(id *PSCIdentification) (string, bool) {
+	if id == nil || id.RegistrationNumber == "" {
+		return "", false
+	}
+	uk := ukCountries[strings.ToLower(strings.TrimSpace(id.CountryRegistered))] ||
+		strings.Contains(strings.ToLower(id.PlaceRegistered), "companies house")
+	if !uk {
+		return "", false
+	}
+
+	n := strings.ToUpper(strings.ReplaceAll(id.RegistrationNumber, " ", ""))
+	digits := strings.TrimLeft(n, "ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	prefix := n[:len(n)-len(digits)]
+	if len(prefix) > 2 || digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return "", false
+	}
+	width := 8 - len(prefix)
+	if len(digits) > width {
+		return "", false
+	}
+	return prefix + strings.Repeat("0", width-len(digits)) + digits, true
+}
+
+// Reasons an ownership chain stops at a node.
+const (
+	ChainStopIndividual   = "individual"
+	ChainStopLegalPerson  = "legal-person"
+	ChainStopSuperSecure  = "super-secure"
+	ChainStopForeign      = "foreign-entity"
+	ChainStopUnidentified = "unidentified"
+	ChainStopCycle        = "cycle"
+	ChainStopDepth        = "depth-limit"
+	ChainStopNoPSCs       = "no-pscs"
+	ChainStopNotFound     = "not-found"
+	ChainStopError        = "error"
+)
+
+// OwnerKindCompany is the kind of the company an ownership chain starts
+// from. Other nodes carry the PSC kind from their self link.
+const OwnerKindCompany = "company"
+
+// OwnershipNode is a company or PSC in an ownership chain. Ownership is the
+// band the node holds in its parent and EffectiveOwnership the band it holds
+// in the root company through every layer above it; both are nil when
+// control is not by shareholding somewhere along the path.
+type OwnershipNode struct {
+	Name               string           `json:"name"`
+	Kind               string           `json:"kind"`
+	CompanyNumber      string           `json:"company_number,omitempty"`
+	CountryRegistered  string           `json:"country_registered,omitempty"`
+	NaturesOfControl   []string         `json:"natures_of_control,omitempty"`
+	Ownership          *OwnershipBand   `json:"ownership,omitempty"`
+	EffectiveOwnership *OwnershipBand   `json:"effective_ownership,omitempty"`
+	Stop               string           `json:"stop,omitempty"`
+	Error              string           `json:"error,omitempty"`
+	Owners             []*OwnershipNode `json:"owners,omitempty"`
+}
+
+// ResolveOwnershipChain builds the ownership tree above a company by
+// following its active corporate PSCs that are registered in the UK and
+// fetching their PSCs in turn, up to maxDepth layers of companies. It stops
+// at individuals, legal persons, super-secure PSCs and foreign entities, and
+// at any company already on the current path. A corporate PSC whose company
+// number is not on the register is marked with ChainStopNotFound, and one
+// whose PSCs cannot be fetched with ChainStopError; only a failure at the
+// root company is returned as an error.
+func (c *Client) ResolveOwnershipChain(ctx context.Context, companyNumber string, maxDepth int) (*OwnershipNode, error) {
+	profile, err := c.GetCompany(ctx, companyNumber)
+	if err != nil {
+		return nil, err
+	}
+	root := &OwnershipNode{
+		Name:          profile.CompanyName,
+		Kind:          OwnerKindCompany,
+		CompanyNumber: profile.CompanyNumber,
+	}
+	if root.CompanyNumber == "" {
+		root.CompanyNumber = companyNumber
+	}
+	path := map[string]bool{root.CompanyNumber: true}
+	full := &OwnershipBand{Min: 100, Max: 100}
+	if err := c.expandOwners(ctx, root, full, 1, max(maxDepth, 1), path); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// expandOwners fills in node's owners from its active PSCs, recursing into
+// UK corporate PSCs. path holds the company numbers from the root down to
+// node.
+func (c *Client) expandOwners(ctx context.Context, node *OwnershipNode, effective *OwnershipBand, depth, maxDepth int, path map[string]bool) error {
+	list, err := c.ListAllPSCs(ctx, node.CompanyNumber, 0)
+	if errors.Is(err, ErrNotFound) {
+		// The root's profile has already been fetched; below it the number
+		// comes from a PSC's registration details and may be wrong.
+		if depth > 1 {
+			if _, err := c.GetCompany(ctx, node.CompanyNumber); errors.Is(err, ErrNotFound) {
+				node.Stop, node.Error = ChainStopNotFound, "company "+node.CompanyNumber+" not found"
+				return nil
+			} else if err != nil {
+				return err
+			}
+		}
+		node.Stop = ChainStopNoPSCs
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, p := range list.Items {
+		if p.CeasedOn != "" {
+			continue
+		}
+		owner := &OwnershipNode{
+			Name:             p.Name,
+			Kind:             pscKind(p),
+			NaturesOfControl: p.NaturesOfControl,
+		}
+		if owner.Name == "" {
+			owner.Name = p.Description
+		}
+		if p.Identification != nil {
+			owner.CountryRegistered = p.Identification.CountryRegistered
+		}
+		if band, ok := ShareOwnership(p.NaturesOfControl); ok {
+			owner.Ownership = &band
+			if effective != nil {
+				e := band.Of(*effective)
+				owner.EffectiveOwnership = &e
+			}
+		}
+		node.Owners = append(node.Owners, owner)
+
+		switch owner.Kind {
+		case PSCKindIndividual, PSCKindIndividualBeneficialOwner:
+			owner.Stop = ChainStopIndividual
+			continue
+		case PSCKindLegalPerson, PSCKindLegalPersonBeneficialOwner:
+			owner.Stop = ChainStopLegalPerson
+			continue
+		case PSCKindSuperSecure, PSCKindSuperSecureBeneficialOwner:
+			owner.Stop = ChainStopSuperSecure
+			continue
+		}
+
+		number, ok := UKCompanyNumber(p.Identification)
+		switch {
+		case !ok && (p.Identification == nil || p.Identification.RegistrationNumber == ""):
+			owner.Stop = ChainStopUnidentified
+			continue
+		case !ok:
+			owner.Stop = ChainStopForeign
+			continue
+		}
+		owner.CompanyNumber = number
+		switch {
+		case path[number]:
+			owner.Stop = ChainStopCycle
+			continue
+		case depth >= maxDepth:
+			owner.Stop = ChainStopDepth
+			continue
+		}
+
+		path[number] = true
+		if err := c.expandOwners(ctx, owner, owner.EffectiveOwnership, depth+1, maxDepth, path); err != nil {
+			owner.Stop, owner.Error = ChainStopError, err.Error()
+		}
+		delete(path, number)
+	}
+	if len(node.Owners) == 0 {
+		node.Stop = ChainStopNoPSCs
+	}
+	return nil
+}
+
+// pscKind returns the kind from a PSC's self link (e.g. corporate-entity),
+// falling back to its kind field without the -person-with-significant-control
+// suffix.
+func pscKind(p PSC) string {
+	if _, kind, _, ok := ParsePSCLink(p.Links["self"]); ok {
+		return kind
+	}
+	return strings.TrimSuffix(p.Kind, "-person-with-significant-control")
+}
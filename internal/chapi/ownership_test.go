@@ -0,0 +1,60 @@
+package chapi_test
+
+import (
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestShareOwnership(t *testing.T) {
+	tests := []struct {
+		natures []string
+		want    chapi.OwnershipBand
+		ok      bool
+	}{
+		{[]string{"voting-rights-75-to-100-percent", "ownership-of-shares-75-to-100-percent"}, chapi.OwnershipBand{Min: 75, Max: 100}, true},
+		{[]string{"ownership-of-shares-25-to-50-percent-as-trust"}, chapi.OwnershipBand{Min: 25, Max: 50}, true},
+		{[]string{"right-to-share-surplus-assets-50-to-75-percent-limited-liability-partnership"}, chapi.OwnershipBand{Min: 50, Max: 75}, true},
+		{[]string{"ownership-of-shares-more-than-25-percent-registered-overseas-entity"}, chapi.OwnershipBand{Min: 25, Max: 100}, true},
+		{[]string{"right-to-appoint-and-remove-directors", "significant-influence-or-control"}, chapi.OwnershipBand{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := chapi.ShareOwnership(tt.natures)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("ShareOwnership(%v) = %v, %v; want %v, %v", tt.natures, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestOwnershipBand_Of(t *testing.T) {
+	got := chapi.OwnershipBand{Min: 50, Max: 75}.Of(chapi.OwnershipBand{Min: 75, Max: 100})
+	if got.String() != "37.5-75%" {
+		t.Errorf("Of() = %s, want 37.5-75%%", got)
+	}
+	got = chapi.OwnershipBand{Min: 25, Max: 50}.Of(chapi.OwnershipBand{Min: 25, Max: 50}).Of(chapi.OwnershipBand{Min: 25, Max: 50})
+	if got.String() != "1.56-12.5%" {
+		t.Errorf("Of() = %s, want 1.56-12.5%%", got)
+	}
+}
+
+func TestUKCompanyNumber(t *testing.T) {
+	tests := []struct {
+		id   *chapi.PSCIdentification
+		want string
+		ok   bool
+	}{
+		{&chapi.PSCIdentification{RegistrationNumber: "13876501", CountryRegistered: "England"}, "13876501", true},
+		{&chapi.PSCIdentification{RegistrationNumber: "1234567", PlaceRegistered: "Companies House, Cardiff"}, "01234567", true},
+		{&chapi.PSCIdentification{RegistrationNumber: "sc 12345", CountryRegistered: "Scotland"}, "SC012345", true},
+		{&chapi.PSCIdentification{RegistrationNumber: "918 273 645", CountryRegistered: "Norway"}, "", false},
+		{&chapi.PSCIdentification{RegistrationNumber: "123456789", CountryRegistered: "England"}, "", false},
+		{&chapi.PSCIdentification{CountryRegistered: "England"}, "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := chapi.UKCompanyNumber(tt.id)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("UKCompanyNumber(%+v) = %q, %v; want %q, %v", tt.id, got, ok, tt.want, tt.ok)
+		}
+	}
+}
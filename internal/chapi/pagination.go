@@ -0,0 +1,57 @@
+package chapi
+
+import (
+	"context"
+	"iter"
+)
+
+// maxPageSize is the largest items_per_page the API accepts on list endpoints.
+const maxPageSize = 100
+
+// PageFunc fetches the page of results beginning at startIndex and returns its
+// items together with the total number of results available.
+type PageFunc[T any] func(ctx context.Context, startIndex int) (items []T, total int, err error)
+
+// Paginate returns an iterator that walks start_index until every result
+// reported by the API has been yielded. If limit is greater than zero the
+// iterator stops after limit items. A fetch error is yielded once and ends
+// the iteration.
+func Paginate[T any](ctx context.Context, limit int, fetch PageFunc[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		seen := 0
+		for {
+			items, total, err := fetch(ctx, seen)
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+				seen++
+				if limit > 0 && seen >= limit {
+					return
+				}
+			}
+			// An empty page guards against totals that overstate what the API
+			// will actually return (search results are capped, for example).
+			if len(items) == 0 || seen >= total {
+				return
+			}
+		}
+	}
+}
+
+// Collect drains a paginated iterator into a slice.
+func Collect[T any](seq iter.Seq2[T, error]) ([]T, error) {
+	var out []T
+	for item, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, item)
+	}
+	return out, nil
+}
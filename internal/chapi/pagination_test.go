@@ -0,0 +1,155 @@
+package chapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestPaginate_WalksAllPages(t *testing.T) {
+	var starts []int
+	fetch := func(ctx context.Context, startIndex int) ([]int, int, error) {
+		starts = append(starts, startIndex)
+		var items []int
+		for i := startIndex; i < startIndex+2 && i < 5; i++ {
+			items = append(items, i)
+		}
+		return items, 5, nil
+	}
+
+	got, err := chapi.Collect(chapi.Paginate(context.Background(), 0, fetch))
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if len(got) != 5 {
+		t.Fatalf("len = %d, want 5", len(got))
+	}
+	if fmt.Sprint(starts) != "[0 2 4]" {
+		t.Errorf("start indexes = %v, want [0 2 4]", starts)
+	}
+}
+
+func TestPaginate_Limit(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context, startIndex int) ([]int, int, error) {
+		calls++
+		return []int{startIndex, startIndex + 1, startIndex + 2}, 100, nil
+	}
+
+	got, err := chapi.Collect(chapi.Paginate(context.Background(), 4, fetch))
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if len(got) != 4 {
+		t.Errorf("len = %d, want 4", len(got))
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestPaginate_StopsOnEmptyPage(t *testing.T) {
+	calls := 0
+	fetch := func(ctx context.Context, startIndex int) ([]int, int, error) {
+		calls++
+		if startIndex >= 3 {
+			return nil, 1000, nil
+		}
+		return []int{1, 2, 3}, 1000, nil
+	}
+
+	got, err := chapi.Collect(chapi.Paginate(context.Background(), 0, fetch))
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("len = %d, want 3", len(got))
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestPaginate_Error(t *testing.T) {
+	boom := errors.New("boom")
+	fetch := func(ctx context.Context, startIndex int) ([]int, int, error) {
+		if startIndex > 0 {
+			return nil, 0, boom
+		}
+		return []int{1}, 2, nil
+	}
+
+	_, err := chapi.Collect(chapi.Paginate(context.Background(), 0, fetch))
+	if !errors.Is(err, boom) {
+		t.Errorf("err = %v, want %v", err, boom)
+	}
+}
+
+func TestListAllFilingHistory(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/00445790/filing-history" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("category"); got != "accounts" {
+			t.Errorf("category = %q, want %q", got, "accounts")
+		}
+		if got := r.URL.Query().Get("items_per_page"); got != "100" {
+			t.Errorf("items_per_page = %q, want %q", got, "100")
+		}
+		start, _ := strconv.Atoi(r.URL.Query().Get("start_index"))
+		switch start {
+		case 0:
+			w.Write([]byte(`{"total_count": 3, "start_index": 0, "items": [{"transaction_id": "a"}, {"transaction_id": "b"}]}`))
+		case 2:
+			w.Write([]byte(`{"total_count": 3, "start_index": 2, "items": [{"transaction_id": "c"}]}`))
+		default:
+			t.Errorf("unexpected start_index: %d", start)
+			w.Write([]byte(`{"total_count": 3, "items": []}`))
+		}
+	})
+
+	result, err := client.ListAllFilingHistory(context.Background(), "00445790", "accounts", 0)
+	if err != nil {
+		t.Fatalf("ListAllFilingHistory() error: %v", err)
+	}
+	if result.TotalCount != 3 {
+		t.Errorf("TotalCount = %d, want 3", result.TotalCount)
+	}
+	if len(result.Items) != 3 {
+		t.Fatalf("Items count = %d, want 3", len(result.Items))
+	}
+	if result.Items[2].TransactionID != "c" {
+		t.Errorf("Items[2].TransactionID = %q, want %q", result.Items[2].TransactionID, "c")
+	}
+}
+
+func TestListAllOfficers_Limit(t *testing.T) {
+	requests := 0
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{
+			"total_results": 500,
+			"active_count": 400,
+			"items": [{"name": "A"}, {"name": "B"}, {"name": "C"}]
+		}`))
+	})
+
+	result, err := client.ListAllOfficers(context.Background(), "00445790", 2)
+	if err != nil {
+		t.Fatalf("ListAllOfficers() error: %v", err)
+	}
+	if len(result.Items) != 2 {
+		t.Errorf("Items count = %d, want 2", len(result.Items))
+	}
+	if result.ActiveCount != 400 {
+		t.Errorf("ActiveCount = %d, want 400", result.ActiveCount)
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+}
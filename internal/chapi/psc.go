@@ -16,6 +16,9 @@ type PSC struct {
 	Nationality       string   `json:"nationality,omitempty"`
 	CountryOfResidence string  `json:"country_of_residence,omitempty"`
 	Address           RegisteredOffice `json:"address"`
+	DateOfBirth       *DateOfBirth `json:"date_of_birth,omitempty"`
+	Identification    *PSCIdentification `json:"identification,omitempty"`
+	Description       string   `json:"description,omitempty"`
 	Links             map[string]string `json:"links,omitempty"`
 }
 
@@ -45,3 +48,26 @@ func (c *Client) ListPSCs(ctx context.Context, companyNumber string, itemsPerPag
 	}
 	return &result, nil
 }
+
+// ListAllPSCs fetches every page of PSCs for a company. If limit is greater
+// than zero, at most limit PSCs are returned.
+func (c *Client) ListAllPSCs(ctx context.Context, companyNumber string, limit int) (*PSCList, error) {
+	var result *PSCList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]PSC, int, error) {
+		page, err := c.ListPSCs(ctx, companyNumber, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
@@ -0,0 +1,242 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// PSC kinds as they appear in the path of a PSC's self link.
+const (
+	PSCKindIndividual                     = "individual"
+	PSCKindCorporateEntity                = "corporate-entity"
+	PSCKindLegalPerson                    = "legal-person"
+	PSCKindSuperSecure                    = "super-secure"
+	PSCKindIndividualBeneficialOwner      = "individual-beneficial-owner"
+	PSCKindCorporateEntityBeneficialOwner = "corporate-entity-beneficial-owner"
+	PSCKindLegalPersonBeneficialOwner     = "legal-person-beneficial-owner"
+	PSCKindSuperSecureBeneficialOwner     = "super-secure-beneficial-owner"
+)
+
+const pscPathSegment = "/persons-with-significant-control/"
+
+// ParsePSCLink splits a PSC self link of the form
+// /company/{number}/persons-with-significant-control/{kind}/{id}.
+func ParsePSCLink(link string) (companyNumber, kind, id string, ok bool) {
+	rest, found := strings.CutPrefix(link, "/company/")
+	if !found {
+		return "", "", "", false
+	}
+	companyNumber, rest, found = strings.Cut(rest, pscPathSegment)
+	if !found {
+		return "", "", "", false
+	}
+	kind, id, found = strings.Cut(rest, "/")
+	if !found || kind == "" || id == "" || strings.Contains(id, "/") {
+		return "", "", "", false
+	}
+	return companyNumber, kind, id, true
+}
+
+// PSCIdentification describes how a corporate or legal person PSC is
+// registered.
+type PSCIdentification struct {
+	LegalAuthority     string `json:"legal_authority,omitempty"`
+	LegalForm          string `json:"legal_form,omitempty"`
+	PlaceRegistered    string `json:"place_registered,omitempty"`
+	RegistrationNumber string `json:"registration_number,omitempty"`
+	CountryRegistered  string `json:"country_registered,omitempty"`
+}
+
+// NameElements holds the parts of an individual's name.
+type NameElements struct {
+	Title      string `json:"title,omitempty"`
+	Forename   string `json:"forename,omitempty"`
+	MiddleName string `json:"middle_name,omitempty"`
+	Surname    string `json:"surname,omitempty"`
+}
+
+// IndividualPSC is an individual person with significant control.
+type IndividualPSC struct {
+	Kind               string            `json:"kind"`
+	Name               string            `json:"name"`
+	NameElements       *NameElements     `json:"name_elements,omitempty"`
+	DateOfBirth        *DateOfBirth      `json:"date_of_birth,omitempty"`
+	Nationality        string            `json:"nationality,omitempty"`
+	CountryOfResidence string            `json:"country_of_residence,omitempty"`
+	Address            RegisteredOffice  `json:"address"`
+	NaturesOfControl   []string          `json:"natures_of_control"`
+	NotifiedOn         string            `json:"notified_on"`
+	CeasedOn           string            `json:"ceased_on,omitempty"`
+	Links              map[string]string `json:"links,omitempty"`
+}
+
+// CorporateEntityPSC is a company or other registered entity with
+// significant control.
+type CorporateEntityPSC struct {
+	Kind             string             `json:"kind"`
+	Name             string             `json:"name"`
+	Identification   *PSCIdentification `json:"identification,omitempty"`
+	Address          RegisteredOffice   `json:"address"`
+	NaturesOfControl []string           `json:"natures_of_control"`
+	NotifiedOn       string             `json:"notified_on"`
+	CeasedOn         string             `json:"ceased_on,omitempty"`
+	Links            map[string]string  `json:"links,omitempty"`
+}
+
+// LegalPersonPSC is a body with its own legal personality but no register
+// entry, such as a government department, with significant control.
+type LegalPersonPSC struct {
+	Kind             string             `json:"kind"`
+	Name             string             `json:"name"`
+	Identification   *PSCIdentification `json:"identification,omitempty"`
+	Address          RegisteredOffice   `json:"address"`
+	NaturesOfControl []string           `json:"natures_of_control"`
+	NotifiedOn       string             `json:"notified_on"`
+	CeasedOn         string             `json:"ceased_on,omitempty"`
+	Links            map[string]string  `json:"links,omitempty"`
+}
+
+// SuperSecurePSC is a PSC whose details are withheld from the public
+// register.
+type SuperSecurePSC struct {
+	Kind        string            `json:"kind"`
+	Description string            `json:"description"`
+	Ceased      bool              `json:"ceased"`
+	Links       map[string]string `json:"links,omitempty"`
+}
+
+// IndividualBeneficialOwner is an individual registered as a beneficial
+// owner of an overseas entity.
+type IndividualBeneficialOwner struct {
+	IndividualPSC
+	IsSanctioned bool `json:"is_sanctioned"`
+}
+
+// CorporateEntityBeneficialOwner is a corporate body registered as a
+// beneficial owner of an overseas entity.
+type CorporateEntityBeneficialOwner struct {
+	CorporateEntityPSC
+	IsSanctioned           bool              `json:"is_sanctioned"`
+	PrincipalOfficeAddress *RegisteredOffice `json:"principal_office_address,omitempty"`
+}
+
+// LegalPersonBeneficialOwner is a government or public authority registered
+// as a beneficial owner of an overseas entity.
+type LegalPersonBeneficialOwner struct {
+	LegalPersonPSC
+	IsSanctioned           bool              `json:"is_sanctioned"`
+	PrincipalOfficeAddress *RegisteredOffice `json:"principal_office_address,omitempty"`
+}
+
+func getPSC[T any](ctx context.Context, c *Client, companyNumber, kind, pscID string) (*T, error) {
+	var result T
+	path := fmt.Sprintf("/company/%s%s%s/%s", companyNumber, pscPathSegment, kind, pscID)
+	if err := c.get(ctx, path, nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetIndividualPSC retrieves an individual PSC.
+func (c *Client) GetIndividualPSC(ctx context.Context, companyNumber, pscID string) (*IndividualPSC, error) {
+	return getPSC[IndividualPSC](ctx, c, companyNumber, PSCKindIndividual, pscID)
+}
+
+// GetCorporateEntityPSC retrieves a corporate entity PSC.
+func (c *Client) GetCorporateEntityPSC(ctx context.Context, companyNumber, pscID string) (*CorporateEntityPSC, error) {
+	return getPSC[CorporateEntityPSC](ctx, c, companyNumber, PSCKindCorporateEntity, pscID)
+}
+
+// GetLegalPersonPSC retrieves a legal person PSC.
+func (c *Client) GetLegalPersonPSC(ctx context.Context, companyNumber, pscID string) (*LegalPersonPSC, error) {
+	return getPSC[LegalPersonPSC](ctx, c, companyNumber, PSCKindLegalPerson, pscID)
+}
+
+// GetSuperSecurePSC retrieves a super-secure PSC.
+func (c *Client) GetSuperSecurePSC(ctx context.Context, companyNumber, pscID string) (*SuperSecurePSC, error) {
+	return getPSC[SuperSecurePSC](ctx, c, companyNumber, PSCKindSuperSecure, pscID)
+}
+
+// GetIndividualBeneficialOwner retrieves an individual beneficial owner.
+func (c *Client) GetIndividualBeneficialOwner(ctx context.Context, companyNumber, pscID string) (*IndividualBeneficialOwner, error) {
+	return getPSC[IndividualBeneficialOwner](ctx, c, companyNumber, PSCKindIndividualBeneficialOwner, pscID)
+}
+
+// GetCorporateEntityBeneficialOwner retrieves a corporate entity beneficial
+// owner.
+func (c *Client) GetCorporateEntityBeneficialOwner(ctx context.Context, companyNumber, pscID string) (*CorporateEntityBeneficialOwner, error) {
+	return getPSC[CorporateEntityBeneficialOwner](ctx, c, companyNumber, PSCKindCorporateEntityBeneficialOwner, pscID)
+}
+
+// GetLegalPersonBeneficialOwner retrieves a legal person beneficial owner.
+func (c *Client) GetLegalPersonBeneficialOwner(ctx context.Context, companyNumber, pscID string) (*LegalPersonBeneficialOwner, error) {
+	return getPSC[LegalPersonBeneficialOwner](ctx, c, companyNumber, PSCKindLegalPersonBeneficialOwner, pscID)
+}
+
+// GetSuperSecureBeneficialOwner retrieves a super-secure beneficial owner.
+func (c *Client) GetSuperSecureBeneficialOwner(ctx context.Context, companyNumber, pscID string) (*SuperSecurePSC, error) {
+	return getPSC[SuperSecurePSC](ctx, c, companyNumber, PSCKindSuperSecureBeneficialOwner, pscID)
+}
+
+// PSCStatement is a statement a company has made about its PSCs, such as
+// that none exist or that one exists but has not been identified.
+type PSCStatement struct {
+	Kind          string            `json:"kind,omitempty"`
+	Statement     string            `json:"statement"`
+	NotifiedOn    string            `json:"notified_on"`
+	CeasedOn      string            `json:"ceased_on,omitempty"`
+	LinkedPSCName string            `json:"linked_psc_name,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+}
+
+// PSCStatementList holds a list of PSC statements.
+type PSCStatementList struct {
+	TotalResults int            `json:"total_results"`
+	ActiveCount  int            `json:"active_count"`
+	CeasedCount  int            `json:"ceased_count"`
+	Items        []PSCStatement `json:"items"`
+	StartIndex   int            `json:"start_index"`
+	ItemsPerPage int            `json:"items_per_page"`
+}
+
+// ListPSCStatements lists the PSC statements for a company.
+func (c *Client) ListPSCStatements(ctx context.Context, companyNumber string, itemsPerPage, startIndex int) (*PSCStatementList, error) {
+	params := url.Values{}
+	if itemsPerPage > 0 {
+		params.Set("items_per_page", fmt.Sprintf("%d", itemsPerPage))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", fmt.Sprintf("%d", startIndex))
+	}
+
+	var result PSCStatementList
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/persons-with-significant-control-statements", companyNumber), params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListAllPSCStatements fetches every page of PSC statements for a company.
+// If limit is greater than zero, at most limit statements are returned.
+func (c *Client) ListAllPSCStatements(ctx context.Context, companyNumber string, limit int) (*PSCStatementList, error) {
+	var result *PSCStatementList
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]PSCStatement, int, error) {
+		page, err := c.ListPSCStatements(ctx, companyNumber, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.TotalResults, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	result.StartIndex = 0
+	result.ItemsPerPage = len(items)
+	return result, nil
+}
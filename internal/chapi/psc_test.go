@@ -4,6 +4,8 @@ import (
 	"context"
 	"net/http"
 	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
 )
 
 func TestListPSCs_Success(t *testing.T) {
@@ -69,3 +71,108 @@ func TestListPSCs_Empty(t *testing.T) {
 		t.Errorf("Items count = %d, want 0", len(result.Items))
 	}
 }
+
+func TestGetCorporateEntityPSC_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/12987654/persons-with-significant-control/corporate-entity/Xb4nC9v" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"kind": "corporate-entity-person-with-significant-control",
+			"name": "NORTHWIND HOLDINGS LIMITED",
+			"natures_of_control": ["ownership-of-shares-75-to-100-percent"],
+			"notified_on": "2022-04-11",
+			"identification": {
+				"legal_authority": "Companies Act 2006",
+				"legal_form": "Private Limited Company",
+				"place_registered": "Companies House",
+				"registration_number": "13876501"
+			}
+		}`))
+	})
+
+	psc, err := client.GetCorporateEntityPSC(context.Background(), "12987654", "Xb4nC9v")
+	if err != nil {
+		t.Fatalf("GetCorporateEntityPSC() error: %v", err)
+	}
+	if psc.Identification == nil {
+		t.Fatal("Identification is nil")
+	}
+	if psc.Identification.RegistrationNumber != "13876501" {
+		t.Errorf("RegistrationNumber = %q, want %q", psc.Identification.RegistrationNumber, "13876501")
+	}
+	if psc.Identification.PlaceRegistered != "Companies House" {
+		t.Errorf("PlaceRegistered = %q, want %q", psc.Identification.PlaceRegistered, "Companies House")
+	}
+}
+
+func TestGetIndividualBeneficialOwner_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/OE012345/persons-with-significant-control/individual-beneficial-owner/Bo1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"kind": "individual-beneficial-owner",
+			"name": "Mr Luca Bianchi",
+			"date_of_birth": {"month": 2, "year": 1964},
+			"natures_of_control": ["over-75-percent-of-shares"],
+			"notified_on": "2023-01-31",
+			"is_sanctioned": true
+		}`))
+	})
+
+	bo, err := client.GetIndividualBeneficialOwner(context.Background(), "OE012345", "Bo1")
+	if err != nil {
+		t.Fatalf("GetIndividualBeneficialOwner() error: %v", err)
+	}
+	if bo.Name != "Mr Luca Bianchi" || !bo.IsSanctioned {
+		t.Errorf("beneficial owner = %+v", bo)
+	}
+	if bo.DateOfBirth == nil || bo.DateOfBirth.Year != 1964 {
+		t.Errorf("DateOfBirth = %+v, want year 1964", bo.DateOfBirth)
+	}
+}
+
+func TestListPSCStatements_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/04567321/persons-with-significant-control-statements" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"total_results": 1,
+			"active_count": 1,
+			"items": [
+				{"statement": "psc-exists-but-not-identified", "notified_on": "2023-11-02"}
+			]
+		}`))
+	})
+
+	result, err := client.ListPSCStatements(context.Background(), "04567321", 0, 0)
+	if err != nil {
+		t.Fatalf("ListPSCStatements() error: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].Statement != "psc-exists-but-not-identified" {
+		t.Errorf("Items = %+v", result.Items)
+	}
+}
+
+func TestParsePSCLink(t *testing.T) {
+	tests := []struct {
+		link              string
+		company, kind, id string
+		ok                bool
+	}{
+		{"/company/12987654/persons-with-significant-control/corporate-entity/Xb4", "12987654", "corporate-entity", "Xb4", true},
+		{"/company/OE012345/persons-with-significant-control/individual-beneficial-owner/Bo1", "OE012345", "individual-beneficial-owner", "Bo1", true},
+		{"/company/12987654/persons-with-significant-control/individual/", "", "", "", false},
+		{"/company/12987654/appointments/abc", "", "", "", false},
+		{"", "", "", "", false},
+	}
+	for _, tt := range tests {
+		company, kind, id, ok := chapi.ParsePSCLink(tt.link)
+		if company != tt.company || kind != tt.kind || id != tt.id || ok != tt.ok {
+			t.Errorf("ParsePSCLink(%q) = %q, %q, %q, %v; want %q, %q, %q, %v",
+				tt.link, company, kind, id, ok, tt.company, tt.kind, tt.id, tt.ok)
+		}
+	}
+}
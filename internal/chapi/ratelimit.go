@@ -0,0 +1,142 @@
+package chapi
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultRateLimit and defaultRateWindow mirror the Companies House quota
+	// of 600 requests per 5 minutes per API key.
+	defaultRateLimit  = 600
+	defaultRateWindow = 5 * time.Minute
+)
+
+// sharedLimiter paces every Client created with New, so separate clients using
+// the same API key in one process draw from a single quota.
+var sharedLimiter = NewRateLimiter(defaultRateLimit, defaultRateWindow)
+
+// RateLimiter paces requests against a fixed-window quota. It starts from a
+// local estimate and is corrected by the X-Ratelimit-* headers the API returns.
+// It is safe for concurrent use.
+type RateLimiter struct {
+	mu        sync.Mutex
+	limit     int
+	window    time.Duration
+	remaining int
+	reset     time.Time
+	notBefore time.Time
+}
+
+// NewRateLimiter creates a limiter allowing limit requests per window.
+func NewRateLimiter(limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		limit:     limit,
+		window:    window,
+		remaining: limit,
+	}
+}
+
+// Wait blocks until a request may be sent under the current quota, or until
+// ctx is done. When the quota runs low, requests are spread over what is left
+// of the window rather than sent in a burst.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	delay := l.reserve(time.Now())
+	if delay <= 0 {
+		return nil
+	}
+	slog.Debug("waiting for rate limit", "delay", delay)
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// reserve claims a request slot and returns how long the caller must wait
+// before using it.
+func (l *RateLimiter) reserve(now time.Time) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	at := now
+	if l.notBefore.After(at) {
+		at = l.notBefore
+	}
+	if !l.reset.After(at) {
+		l.remaining = l.limit
+		l.reset = at.Add(l.window)
+	}
+	if l.remaining <= 0 {
+		// The slot falls in the next window; everyone queued behind us must
+		// wait for it too.
+		at = l.reset
+		l.remaining = l.limit
+		l.reset = at.Add(l.window)
+	}
+	l.remaining--
+	l.notBefore = at
+
+	// Once under a tenth of the quota, space the remaining requests evenly
+	// until the window resets.
+	if l.remaining < l.limit/10 {
+		l.notBefore = at.Add(l.reset.Sub(at) / time.Duration(l.remaining+1))
+	}
+	return at.Sub(now)
+}
+
+// Observe updates the limiter from the X-Ratelimit-Limit, X-Ratelimit-Remain
+// and X-Ratelimit-Reset response headers. Missing or malformed headers are
+// ignored.
+func (l *RateLimiter) Observe(h http.Header) {
+	limit, limitErr := strconv.Atoi(h.Get("X-Ratelimit-Limit"))
+	remain, remainErr := strconv.Atoi(h.Get("X-Ratelimit-Remain"))
+	reset, resetErr := strconv.ParseInt(h.Get("X-Ratelimit-Reset"), 10, 64)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limitErr == nil && limit > 0 {
+		l.limit = limit
+	}
+	newWindow := false
+	if resetErr == nil && reset > 0 {
+		t := time.Unix(reset, 0)
+		newWindow = !t.Equal(l.reset)
+		l.reset = t
+	}
+	if remainErr == nil && remain >= 0 {
+		// Within one window the server's count lags our in-flight
+		// reservations, so only let it lower the local estimate.
+		if newWindow || remain < l.remaining {
+			l.remaining = remain
+		}
+	}
+}
+
+// exhaust marks the quota as used up until the current reset time, as the
+// server does when it answers 429. If no reset time in the future is known,
+// the quota is held back for fallback instead.
+func (l *RateLimiter) exhaust(now time.Time, fallback time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.remaining = 0
+	if !l.reset.After(now) {
+		l.reset = now.Add(fallback)
+	}
+}
+
+// Remaining returns the estimated number of requests left in the current
+// window and when that window resets.
+func (l *RateLimiter) Remaining() (int, time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.remaining, l.reset
+}
@@ -0,0 +1,91 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestRateLimiter_WaitsForNextWindow(t *testing.T) {
+	l := chapi.NewRateLimiter(2, 300*time.Millisecond)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("Wait() error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if elapsed := time.Since(start); elapsed < 250*time.Millisecond {
+		t.Errorf("third request went after %v, want it held until the window resets", elapsed)
+	}
+}
+
+func TestRateLimiter_WaitCancelled(t *testing.T) {
+	l := chapi.NewRateLimiter(1, time.Hour)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait() error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("Wait() should fail once the context is done")
+	}
+}
+
+func TestRateLimiter_Observe(t *testing.T) {
+	l := chapi.NewRateLimiter(600, 5*time.Minute)
+	reset := time.Now().Add(2 * time.Minute).Truncate(time.Second)
+
+	h := http.Header{}
+	h.Set("X-Ratelimit-Limit", "600")
+	h.Set("X-Ratelimit-Remain", "42")
+	h.Set("X-Ratelimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	l.Observe(h)
+
+	remaining, gotReset := l.Remaining()
+	if remaining != 42 {
+		t.Errorf("remaining = %d, want 42", remaining)
+	}
+	if !gotReset.Equal(reset) {
+		t.Errorf("reset = %v, want %v", gotReset, reset)
+	}
+}
+
+func TestClient_RateLimitedWaitsForReset(t *testing.T) {
+	calls := 0
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("X-Ratelimit-Limit", "600")
+			w.Header().Set("X-Ratelimit-Remain", "0")
+			w.Header().Set("X-Ratelimit-Reset", strconv.FormatInt(time.Now().Add(time.Second).Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"company_number": "00445790"}`))
+	})
+
+	profile, err := client.GetCompany(context.Background(), "00445790")
+	if err != nil {
+		t.Fatalf("GetCompany() error: %v", err)
+	}
+	if profile.CompanyNumber != "00445790" {
+		t.Errorf("CompanyNumber = %q, want %q", profile.CompanyNumber, "00445790")
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
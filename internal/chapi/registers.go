@@ -0,0 +1,64 @@
+package chapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// RegisterTypes lists the statutory registers a company can report the
+// location of, in the order Companies House presents them.
+var RegisterTypes = []string{
+	"directors",
+	"secretaries",
+	"persons_with_significant_control",
+	"usual_residential_address",
+	"members",
+	"llp_members",
+	"llp_usual_residential_address",
+}
+
+// RegisterLocation records one move of a register. RegisterMovedTo is one of
+// registered-office, single-alternative-inspection-location, public-register
+// or unspecified-location.
+type RegisterLocation struct {
+	MovedOn         string            `json:"moved_on"`
+	RegisterMovedTo string            `json:"register_moved_to"`
+	Links           map[string]string `json:"links,omitempty"`
+}
+
+// Register is the location history of one statutory register, most recent
+// move first.
+type Register struct {
+	RegisterType string             `json:"register_type"`
+	Items        []RegisterLocation `json:"items"`
+	Links        map[string]string  `json:"links,omitempty"`
+}
+
+// Current returns the register's present location, or ok false if no move
+// has been recorded.
+func (r Register) Current() (loc RegisterLocation, ok bool) {
+	if len(r.Items) == 0 {
+		return RegisterLocation{}, false
+	}
+	return r.Items[0], true
+}
+
+// CompanyRegisters holds where a company keeps its statutory registers,
+// keyed by register type. Registers that have never moved from the
+// registered office are absent.
+type CompanyRegisters struct {
+	CompanyNumber string              `json:"company_number"`
+	Kind          string              `json:"kind,omitempty"`
+	Registers     map[string]Register `json:"registers"`
+	Links         map[string]string   `json:"links,omitempty"`
+}
+
+// GetRegisters retrieves the locations of a company's statutory registers.
+// The API returns 404 for companies that have never filed a register move.
+func (c *Client) GetRegisters(ctx context.Context, companyNumber string) (*CompanyRegisters, error) {
+	var result CompanyRegisters
+	if err := c.get(ctx, fmt.Sprintf("/company/%s/registers", companyNumber), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
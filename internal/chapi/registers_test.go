@@ -0,0 +1,54 @@
+package chapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestGetRegisters_Success(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/company/12987654/registers" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		w.Write([]byte(`{
+			"company_number": "12987654",
+			"kind": "registers",
+			"registers": {
+				"members": {
+					"register_type": "members",
+					"items": [
+						{"moved_on": "2023-05-15", "register_moved_to": "registered-office"},
+						{"moved_on": "2021-03-01", "register_moved_to": "single-alternative-inspection-location"}
+					]
+				}
+			}
+		}`))
+	})
+
+	result, err := client.GetRegisters(context.Background(), "12987654")
+	if err != nil {
+		t.Fatalf("GetRegisters() error: %v", err)
+	}
+	loc, ok := result.Registers["members"].Current()
+	if !ok || loc.RegisterMovedTo != "registered-office" || loc.MovedOn != "2023-05-15" {
+		t.Errorf("Current() = %+v, %v; want the 2023 move", loc, ok)
+	}
+	if _, ok := result.Registers["directors"].Current(); ok {
+		t.Error("Current() ok for a register with no moves")
+	}
+}
+
+func TestGetRegisters_NoneFiled(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"error": "company-registers-not-found", "type": "ch:service"}]}`))
+	})
+
+	if _, err := client.GetRegisters(context.Background(), "00445790"); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
@@ -0,0 +1,366 @@
+package chapi
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Result kinds returned by SearchItem.ResultKind.
+const (
+	SearchKindCompany             = "company"
+	SearchKindOfficer             = "officer"
+	SearchKindDisqualifiedOfficer = "disqualified-officer"
+)
+
+// SearchItem is one entry in the mixed result list from SearchAll. Which
+// fields are set depends on Kind; Title, Description and AddressSnippet are
+// common to every kind.
+type SearchItem struct {
+	Kind           string            `json:"kind"`
+	Title          string            `json:"title"`
+	Description    string            `json:"description,omitempty"`
+	AddressSnippet string            `json:"address_snippet,omitempty"`
+	Snippet        string            `json:"snippet,omitempty"`
+	Address        *RegisteredOffice `json:"address,omitempty"`
+	CompanyNumber  string            `json:"company_number,omitempty"`
+	CompanyStatus  string            `json:"company_status,omitempty"`
+	CompanyType    string            `json:"company_type,omitempty"`
+	DateOfCreation string            `json:"date_of_creation,omitempty"`
+	Links          map[string]string `json:"links,omitempty"`
+}
+
+// ResultKind returns the kind without its "searchresults#" prefix: one of the
+// SearchKind constants.
+func (i SearchItem) ResultKind() string {
+	return strings.TrimPrefix(i.Kind, "searchresults#")
+}
+
+// SearchAllResult holds the ranked results of a search across companies,
+// officers and disqualified officers.
+type SearchAllResult struct {
+	TotalResults int          `json:"total_results"`
+	Items        []SearchItem `json:"items"`
+	StartIndex   int          `json:"start_index"`
+	ItemsPerPage int          `json:"items_per_page"`
+}
+
+// SearchAll searches companies, officers and disqualified officers at once.
+func (c *Client) SearchAll(ctx context.Context, query string, itemsPerPage, startIndex int) (*SearchAllResult, error) {
+	params := url.Values{"q": {query}}
+	if itemsPerPage > 0 {
+		params.Set("items_per_page", strconv.Itoa(itemsPerPage))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", strconv.Itoa(startIndex))
+	}
+
+	var result SearchAllResult
+	if err := c.get(ctx, "/search", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SearchAllPages returns an iterator over every SearchAll result in ranked
+// order, fetching pages as it goes. If limit is greater than zero the
+// iterator stops after limit results.
+func (c *Client) SearchAllPages(ctx context.Context, query string, limit int) iter.Seq2[SearchItem, error] {
+	return Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]SearchItem, int, error) {
+		page, err := c.SearchAll(ctx, query, maxPageSize, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		return page.Items, page.TotalResults, nil
+	})
+}
+
+// maxAdvancedPageSize is the largest size the advanced search endpoint accepts.
+const maxAdvancedPageSize = 5000
+
+// AdvancedSearchParams holds the filters for an advanced company search. Empty
+// fields are not sent; slice fields match any of their values.
+type AdvancedSearchParams struct {
+	NameIncludes     string   `json:"company_name_includes,omitempty"`
+	NameExcludes     string   `json:"company_name_excludes,omitempty"`
+	Status           []string `json:"company_status,omitempty"`
+	Subtype          []string `json:"company_subtype,omitempty"`
+	Type             []string `json:"company_type,omitempty"`
+	SICCodes         []string `json:"sic_codes,omitempty"`
+	Location         string   `json:"location,omitempty"`
+	IncorporatedFrom string   `json:"incorporated_from,omitempty"`
+	IncorporatedTo   string   `json:"incorporated_to,omitempty"`
+	DissolvedFrom    string   `json:"dissolved_from,omitempty"`
+	DissolvedTo      string   `json:"dissolved_to,omitempty"`
+}
+
+// Values encodes the filters as query parameters.
+func (p AdvancedSearchParams) Values() url.Values {
+	v := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			v.Set(key, value)
+		}
+	}
+	add := func(key string, values []string) {
+		for _, value := range values {
+			if value != "" {
+				v.Add(key, value)
+			}
+		}
+	}
+	set("company_name_includes", p.NameIncludes)
+	set("company_name_excludes", p.NameExcludes)
+	add("company_status", p.Status)
+	add("company_subtype", p.Subtype)
+	add("company_type", p.Type)
+	add("sic_codes", p.SICCodes)
+	set("location", p.Location)
+	set("incorporated_from", p.IncorporatedFrom)
+	set("incorporated_to", p.IncorporatedTo)
+	set("dissolved_from", p.DissolvedFrom)
+	set("dissolved_to", p.DissolvedTo)
+	return v
+}
+
+// AdvancedSearchItem is a company returned by the advanced search endpoint.
+type AdvancedSearchItem struct {
+	CompanyName      string            `json:"company_name"`
+	CompanyNumber    string            `json:"company_number"`
+	CompanyStatus    string            `json:"company_status"`
+	CompanyType      string            `json:"company_type"`
+	CompanySubtype   string            `json:"company_subtype,omitempty"`
+	Kind             string            `json:"kind,omitempty"`
+	DateOfCreation   string            `json:"date_of_creation,omitempty"`
+	DateOfCessation  string            `json:"date_of_cessation,omitempty"`
+	RegisteredOffice RegisteredOffice  `json:"registered_office_address"`
+	SICCodes         []string          `json:"sic_codes,omitempty"`
+	Links            map[string]string `json:"links,omitempty"`
+}
+
+// AdvancedSearchResult holds advanced company search results.
+type AdvancedSearchResult struct {
+	Hits   int                  `json:"hits"`
+	Kind   string               `json:"kind,omitempty"`
+	TopHit *AdvancedSearchItem  `json:"top_hit,omitempty"`
+	Items  []AdvancedSearchItem `json:"items"`
+}
+
+// AdvancedSearchCompanies runs an advanced company search, returning size
+// results from startIndex. The API answers a search with no matches with a
+// 404, which is reported as an empty result.
+func (c *Client) AdvancedSearchCompanies(ctx context.Context, params AdvancedSearchParams, size, startIndex int) (*AdvancedSearchResult, error) {
+	q := params.Values()
+	if size > 0 {
+		q.Set("size", strconv.Itoa(size))
+	}
+	if startIndex > 0 {
+		q.Set("start_index", strconv.Itoa(startIndex))
+	}
+
+	var result AdvancedSearchResult
+	if err := c.get(ctx, "/advanced-search/companies", q, &result); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &AdvancedSearchResult{Items: []AdvancedSearchItem{}}, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AdvancedSearchAllCompanies fetches every page of an advanced company search.
+// If limit is greater than zero, at most limit results are returned.
+func (c *Client) AdvancedSearchAllCompanies(ctx context.Context, params AdvancedSearchParams, limit int) (*AdvancedSearchResult, error) {
+	size := maxAdvancedPageSize
+	if limit > 0 {
+		size = min(limit, size)
+	}
+	var result *AdvancedSearchResult
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]AdvancedSearchItem, int, error) {
+		page, err := c.AdvancedSearchCompanies(ctx, params, size, startIndex)
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		return page.Items, page.Hits, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	return result, nil
+}
+
+// AlphabeticalSearchItem is a company returned by the alphabetical search
+// endpoint. OrderedAlphaKeyWithID is the key used to page above or below it.
+type AlphabeticalSearchItem struct {
+	CompanyName           string            `json:"company_name"`
+	CompanyNumber         string            `json:"company_number"`
+	CompanyStatus         string            `json:"company_status"`
+	CompanyType           string            `json:"company_type,omitempty"`
+	Kind                  string            `json:"kind,omitempty"`
+	OrderedAlphaKeyWithID string            `json:"ordered_alpha_key_with_id"`
+	Links                 map[string]string `json:"links,omitempty"`
+}
+
+// AlphabeticalSearchResult holds the companies either side of a name on the
+// alphabetical register. TopHit is the closest match to the query.
+type AlphabeticalSearchResult struct {
+	Kind   string                   `json:"kind,omitempty"`
+	TopHit *AlphabeticalSearchItem  `json:"top_hit,omitempty"`
+	Items  []AlphabeticalSearchItem `json:"items"`
+}
+
+// AlphabeticalSearchCompanies lists up to size companies around query in
+// alphabetical order. Pass the OrderedAlphaKeyWithID of the first item as
+// searchAbove for the page before, or of the last item as searchBelow for the
+// page after; both empty centres the page on the query.
+func (c *Client) AlphabeticalSearchCompanies(ctx context.Context, query, searchAbove, searchBelow string, size int) (*AlphabeticalSearchResult, error) {
+	params := url.Values{"q": {query}}
+	if searchAbove != "" {
+		params.Set("search_above", searchAbove)
+	}
+	if searchBelow != "" {
+		params.Set("search_below", searchBelow)
+	}
+	if size > 0 {
+		params.Set("size", strconv.Itoa(size))
+	}
+
+	var result AlphabeticalSearchResult
+	if err := c.get(ctx, "/alphabetical-search/companies", params, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Search types accepted by DissolvedSearchCompanies.
+const (
+	DissolvedSearchAlphabetical = "alphabetical"
+	DissolvedSearchBestMatch    = "best-match"
+	DissolvedSearchPreviousName = "previous-name-dissolved"
+)
+
+// DissolvedSearchItem is a company returned by the dissolved search endpoint.
+// MatchedPreviousCompanyName is set when a previous-name search matched one of
+// the company's former names rather than its last.
+type DissolvedSearchItem struct {
+	CompanyName                string                `json:"company_name"`
+	CompanyNumber              string                `json:"company_number"`
+	CompanyStatus              string                `json:"company_status"`
+	Kind                       string                `json:"kind,omitempty"`
+	DateOfCreation             string                `json:"date_of_creation,omitempty"`
+	DateOfCessation            string                `json:"date_of_cessation,omitempty"`
+	RegisteredOffice           *RegisteredOffice     `json:"registered_office_address,omitempty"`
+	PreviousCompanyNames       []PreviousCompanyName `json:"previous_company_names,omitempty"`
+	MatchedPreviousCompanyName *PreviousCompanyName  `json:"matched_previous_company_name,omitempty"`
+	OrderedAlphaKeyWithID      string                `json:"ordered_alpha_key_with_id,omitempty"`
+}
+
+// DissolvedSearchResult holds dissolved company search results.
+type DissolvedSearchResult struct {
+	Hits   int                   `json:"hits"`
+	Kind   string                `json:"kind,omitempty"`
+	TopHit *DissolvedSearchItem  `json:"top_hit,omitempty"`
+	Items  []DissolvedSearchItem `json:"items"`
+}
+
+// DissolvedSearchCompanies searches dissolved companies by name. searchType is
+// one of the DissolvedSearch constants; previous-name-dissolved also matches
+// names a company used before it was dissolved. startIndex has no effect on
+// alphabetical searches, which page with DissolvedAlphabeticalSearchCompanies.
+// The API answers a search with no matches with a 404, which is reported as
+// an empty result.
+func (c *Client) DissolvedSearchCompanies(ctx context.Context, query, searchType string, size, startIndex int) (*DissolvedSearchResult, error) {
+	params := url.Values{
+		"q":           {query},
+		"search_type": {searchType},
+	}
+	if size > 0 {
+		params.Set("size", strconv.Itoa(size))
+	}
+	if startIndex > 0 {
+		params.Set("start_index", strconv.Itoa(startIndex))
+	}
+	return c.dissolvedSearch(ctx, params)
+}
+
+// DissolvedAlphabeticalSearchCompanies lists up to size dissolved companies in
+// alphabetical order from query. Pass the OrderedAlphaKeyWithID of the first
+// item as searchAbove for the page before, or of the last item as searchBelow
+// for the page after.
+func (c *Client) DissolvedAlphabeticalSearchCompanies(ctx context.Context, query, searchAbove, searchBelow string, size int) (*DissolvedSearchResult, error) {
+	params := url.Values{
+		"q":           {query},
+		"search_type": {DissolvedSearchAlphabetical},
+	}
+	if searchAbove != "" {
+		params.Set("search_above", searchAbove)
+	}
+	if searchBelow != "" {
+		params.Set("search_below", searchBelow)
+	}
+	if size > 0 {
+		params.Set("size", strconv.Itoa(size))
+	}
+	return c.dissolvedSearch(ctx, params)
+}
+
+func (c *Client) dissolvedSearch(ctx context.Context, params url.Values) (*DissolvedSearchResult, error) {
+	var result DissolvedSearchResult
+	if err := c.get(ctx, "/dissolved-search/companies", params, &result); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return &DissolvedSearchResult{Items: []DissolvedSearchItem{}}, nil
+		}
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DissolvedSearchAllCompanies fetches every page of a dissolved company
+// search. If limit is greater than zero, at most limit results are returned.
+// Alphabetical searches page forwards from the query with search_below until
+// the register runs out, so they should normally be given a limit.
+func (c *Client) DissolvedSearchAllCompanies(ctx context.Context, query, searchType string, limit int) (*DissolvedSearchResult, error) {
+	var result *DissolvedSearchResult
+	below := ""
+	items, err := Collect(Paginate(ctx, limit, func(ctx context.Context, startIndex int) ([]DissolvedSearchItem, int, error) {
+		var page *DissolvedSearchResult
+		var err error
+		if searchType == DissolvedSearchAlphabetical {
+			page, err = c.DissolvedAlphabeticalSearchCompanies(ctx, query, "", below, maxPageSize)
+		} else {
+			page, err = c.DissolvedSearchCompanies(ctx, query, searchType, maxPageSize, startIndex)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		if result == nil {
+			result = page
+		}
+		if searchType != DissolvedSearchAlphabetical {
+			return page.Items, page.Hits, nil
+		}
+		// Alphabetical pages carry no usable total: carry on from the last
+		// key until a page comes back empty or fails to move forward.
+		if len(page.Items) == 0 || page.Items[len(page.Items)-1].OrderedAlphaKeyWithID == below {
+			return nil, 0, nil
+		}
+		below = page.Items[len(page.Items)-1].OrderedAlphaKeyWithID
+		return page.Items, math.MaxInt, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+	result.Items = items
+	if searchType == DissolvedSearchAlphabetical {
+		result.Hits = len(items)
+	}
+	return result, nil
+}
@@ -0,0 +1,262 @@
+package chapi_test
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+func TestAdvancedSearchCompanies_Params(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/advanced-search/companies" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if got := q["company_status"]; !slices.Equal(got, []string{"active", "open"}) {
+			t.Errorf("company_status = %v", got)
+		}
+		for key, want := range map[string]string{
+			"company_name_includes": "northwind",
+			"company_type":          "ltd",
+			"sic_codes":             "62020",
+			"location":              "Leeds",
+			"incorporated_from":     "2020-01-01",
+			"size":                  "50",
+			"start_index":           "100",
+		} {
+			if got := q.Get(key); got != want {
+				t.Errorf("%s = %q, want %q", key, got, want)
+			}
+		}
+		for _, key := range []string{"company_name_excludes", "dissolved_from", "dissolved_to", "company_subtype"} {
+			if q.Has(key) {
+				t.Errorf("unexpected %s parameter", key)
+			}
+		}
+		w.Write([]byte(`{
+			"hits": 1,
+			"kind": "search#advanced-search",
+			"items": [{
+				"company_name": "NORTHWIND ANALYTICS LTD",
+				"company_number": "12987654",
+				"company_status": "active",
+				"company_type": "ltd",
+				"date_of_creation": "2020-11-03",
+				"registered_office_address": {"locality": "Leeds", "postal_code": "LS1 2NE"},
+				"sic_codes": ["62020", "62090"]
+			}]
+		}`))
+	})
+
+	result, err := client.AdvancedSearchCompanies(context.Background(), chapi.AdvancedSearchParams{
+		NameIncludes:     "northwind",
+		Status:           []string{"active", "open"},
+		Type:             []string{"ltd"},
+		SICCodes:         []string{"62020"},
+		Location:         "Leeds",
+		IncorporatedFrom: "2020-01-01",
+	}, 50, 100)
+	if err != nil {
+		t.Fatalf("AdvancedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 1 || len(result.Items) != 1 {
+		t.Fatalf("Hits = %d, Items = %d, want 1 and 1", result.Hits, len(result.Items))
+	}
+	if item := result.Items[0]; item.CompanyType != "ltd" || item.RegisteredOffice.Locality != "Leeds" {
+		t.Errorf("item = %+v", item)
+	}
+}
+
+func TestAdvancedSearchCompanies_NoMatches(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	result, err := client.AdvancedSearchCompanies(context.Background(), chapi.AdvancedSearchParams{Location: "Atlantis"}, 0, 0)
+	if err != nil {
+		t.Fatalf("AdvancedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 0 || len(result.Items) != 0 {
+		t.Errorf("result = %+v, want empty", result)
+	}
+}
+
+func TestAdvancedSearchAllCompanies_Pages(t *testing.T) {
+	var starts []string
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		starts = append(starts, q.Get("start_index"))
+		if got := q.Get("size"); got != "5000" {
+			t.Errorf("size = %q, want 5000", got)
+		}
+		// Serve two results per page, as if the API capped the page size.
+		start, _ := strconv.Atoi(q.Get("start_index"))
+		w.Write([]byte(`{"hits": 5, "items": [`))
+		for i := start; i < min(start+2, 5); i++ {
+			if i > start {
+				w.Write([]byte(","))
+			}
+			w.Write([]byte(`{"company_number": "0000000` + strconv.Itoa(i) + `"}`))
+		}
+		w.Write([]byte(`]}`))
+	})
+
+	result, err := client.AdvancedSearchAllCompanies(context.Background(), chapi.AdvancedSearchParams{Location: "Leeds"}, 0)
+	if err != nil {
+		t.Fatalf("AdvancedSearchAllCompanies() error: %v", err)
+	}
+	if len(result.Items) != 5 || result.Items[4].CompanyNumber != "00000004" {
+		t.Errorf("Items = %+v, want 5 companies", result.Items)
+	}
+	if !slices.Equal(starts, []string{"", "2", "4"}) {
+		t.Errorf("start_index requests = %v", starts)
+	}
+}
+
+func TestAlphabeticalSearchCompanies_Params(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/alphabetical-search/companies" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("q") != "tesco" || q.Get("search_below") != "TESCOPLC:00445790" || q.Get("size") != "5" {
+			t.Errorf("query = %v", q)
+		}
+		if q.Has("search_above") {
+			t.Error("unexpected search_above parameter")
+		}
+		w.Write([]byte(`{
+			"kind": "search#alphabetical-search",
+			"items": [{
+				"company_name": "TESCO STORES LIMITED",
+				"company_number": "00519500",
+				"company_status": "active",
+				"ordered_alpha_key_with_id": "TESCOSTORES:00519500"
+			}]
+		}`))
+	})
+
+	result, err := client.AlphabeticalSearchCompanies(context.Background(), "tesco", "", "TESCOPLC:00445790", 5)
+	if err != nil {
+		t.Fatalf("AlphabeticalSearchCompanies() error: %v", err)
+	}
+	if len(result.Items) != 1 || result.Items[0].OrderedAlphaKeyWithID != "TESCOSTORES:00519500" {
+		t.Errorf("Items = %+v", result.Items)
+	}
+}
+
+func TestDissolvedSearchCompanies_PreviousName(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dissolved-search/companies" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("search_type"); got != chapi.DissolvedSearchPreviousName {
+			t.Errorf("search_type = %q, want %q", got, chapi.DissolvedSearchPreviousName)
+		}
+		w.Write([]byte(`{
+			"hits": 1,
+			"kind": "search#dissolved",
+			"items": [{
+				"company_name": "OLD MILL BAKERY LIMITED",
+				"company_number": "06123789",
+				"company_status": "dissolved",
+				"date_of_cessation": "2019-03-12",
+				"matched_previous_company_name": {"name": "OLD MILL TRADING (NORTH) LIMITED", "ceased_on": "2011-06-01"}
+			}]
+		}`))
+	})
+
+	result, err := client.DissolvedSearchCompanies(context.Background(), "old mill trading", chapi.DissolvedSearchPreviousName, 0, 0)
+	if err != nil {
+		t.Fatalf("DissolvedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 1 || len(result.Items) != 1 {
+		t.Fatalf("Hits = %d, Items = %d, want 1 and 1", result.Hits, len(result.Items))
+	}
+	if m := result.Items[0].MatchedPreviousCompanyName; m == nil || m.CeasedOn != "2011-06-01" {
+		t.Errorf("MatchedPreviousCompanyName = %+v", m)
+	}
+}
+
+func TestDissolvedSearchAllCompanies_AlphabeticalPagesBelow(t *testing.T) {
+	pages := map[string]string{
+		"":        `{"items": [{"company_number": "1", "ordered_alpha_key_with_id": "MILLA:1"}, {"company_number": "2", "ordered_alpha_key_with_id": "MILLB:2"}]}`,
+		"MILLB:2": `{"items": [{"company_number": "3", "ordered_alpha_key_with_id": "MILLC:3"}]}`,
+		"MILLC:3": ``,
+	}
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if got := q.Get("search_type"); got != chapi.DissolvedSearchAlphabetical {
+			t.Errorf("search_type = %q, want %q", got, chapi.DissolvedSearchAlphabetical)
+		}
+		if q.Has("start_index") {
+			t.Errorf("alphabetical search sent start_index=%s", q.Get("start_index"))
+		}
+		body := pages[q.Get("search_below")]
+		if body == "" {
+			w.WriteHeader(http.StatusNotFound)
+			body = `{"errors": [{"error": "no-results-found", "type": "ch:service"}]}`
+		}
+		w.Write([]byte(body))
+	})
+
+	result, err := client.DissolvedSearchAllCompanies(context.Background(), "mill", chapi.DissolvedSearchAlphabetical, 0)
+	if err != nil {
+		t.Fatalf("DissolvedSearchAllCompanies() error: %v", err)
+	}
+	var got []string
+	for _, item := range result.Items {
+		got = append(got, item.CompanyNumber)
+	}
+	if !slices.Equal(got, []string{"1", "2", "3"}) || result.Hits != 3 {
+		t.Errorf("companies = %v, Hits = %d, want [1 2 3] and 3", got, result.Hits)
+	}
+}
+
+func TestDissolvedSearchCompanies_NoMatches(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"errors": [{"error": "no-results-found", "type": "ch:service"}]}`))
+	})
+
+	result, err := client.DissolvedSearchCompanies(context.Background(), "zzz", chapi.DissolvedSearchBestMatch, 0, 0)
+	if err != nil {
+		t.Fatalf("DissolvedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 0 || result.Items == nil || len(result.Items) != 0 {
+		t.Errorf("result = %+v, want an empty result", result)
+	}
+}
+
+func TestSearchAll_MixedKinds(t *testing.T) {
+	_, client := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if q := r.URL.Query().Get("q"); q != "price" {
+			t.Errorf("q = %q, want %q", q, "price")
+		}
+		w.Write([]byte(`{
+			"total_results": 2,
+			"items": [
+				{"kind": "searchresults#officer", "title": "PRICE, Gareth Wyn", "address_snippet": "Canal Street, Manchester"},
+				{"kind": "searchresults#company", "title": "PRICE BROTHERS LIMITED", "company_number": "01234567"}
+			]
+		}`))
+	})
+
+	var kinds []string
+	for item, err := range client.SearchAllPages(context.Background(), "price", 0) {
+		if err != nil {
+			t.Fatalf("SearchAllPages() error: %v", err)
+		}
+		kinds = append(kinds, item.ResultKind())
+	}
+	if !slices.Equal(kinds, []string{chapi.SearchKindOfficer, chapi.SearchKindCompany}) {
+		t.Errorf("kinds = %v", kinds)
+	}
+}
@@ -0,0 +1,255 @@
+package chapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const streamBaseURL = "https://stream.companieshouse.gov.uk"
+
+// StreamResources are the streams offered by the Streaming API. Each is
+// served at /{resource}.
+var StreamResources = []string{
+	"companies",
+	"filings",
+	"officers",
+	"persons-with-significant-control",
+	"charges",
+	"insolvency-cases",
+	"disqualified-officers",
+	"company-exemptions",
+	"persons-with-significant-control-statements",
+}
+
+const (
+	defaultStreamIdleTimeout = 90 * time.Second
+	maxStreamBackoff         = time.Minute
+)
+
+// ErrStopStream can be returned by a StreamHandler to end the stream
+// without error.
+var ErrStopStream = errors.New("stop stream")
+
+// StreamClient wraps the Companies House Streaming API, which pushes changes
+// to the register as long-lived chunked responses of newline-delimited JSON.
+type StreamClient struct {
+	apiKey     string
+	httpClient *http.Client
+	baseURL    string
+	opts       options
+}
+
+// NewStreamClient creates a Streaming API client. apiKey is a stream key,
+// which Companies House issues separately from REST API keys. It accepts the
+// same options as New; the timeout applies only until response headers
+// arrive, the retry policy's backoff paces reconnects (capped at a minute),
+// and the rate limit and cache options are ignored.
+func NewStreamClient(apiKey string, opts ...Option) *StreamClient {
+	o := newOptions(streamBaseURL, opts)
+	return &StreamClient{
+		apiKey:     apiKey,
+		httpClient: o.buildStreamingHTTPClient(),
+		baseURL:    o.baseURL,
+		opts:       o,
+	}
+}
+
+// NewStreamClientWithBaseURL creates a stream client with a custom base URL
+// (for testing).
+func NewStreamClientWithBaseURL(apiKey, base string, opts ...Option) *StreamClient {
+	return NewStreamClient(apiKey, append([]Option{WithBaseURL(base)}, opts...)...)
+}
+
+// StreamEvent is one change published on a stream. Data holds the changed
+// resource in the same shape the REST API returns it.
+type StreamEvent struct {
+	ResourceKind string          `json:"resource_kind"`
+	ResourceURI  string          `json:"resource_uri"`
+	ResourceID   string          `json:"resource_id"`
+	Data         json.RawMessage `json:"data"`
+	Event        StreamEventInfo `json:"event"`
+}
+
+// StreamEventInfo describes when and how a resource changed.
+type StreamEventInfo struct {
+	Timepoint     int64    `json:"timepoint"`
+	PublishedAt   string   `json:"published_at"`
+	Type          string   `json:"type"`
+	FieldsChanged []string `json:"fields_changed,omitempty"`
+}
+
+// CompanyNumber returns the company the event's resource belongs to, parsed
+// from its resource URI, or "" for resources outside a company (such as
+// disqualified officers).
+func (e StreamEvent) CompanyNumber() string {
+	rest, ok := strings.CutPrefix(e.ResourceURI, "/company/")
+	if !ok {
+		return ""
+	}
+	number, _, _ := strings.Cut(rest, "/")
+	return number
+}
+
+// StreamHandler is called with each event in order. Returning ErrStopStream
+// ends the stream cleanly; any other error ends it with that error.
+type StreamHandler func(StreamEvent) error
+
+// StreamOptions controls where a stream starts and how it recovers.
+type StreamOptions struct {
+	// Timepoint is the first event to deliver. Zero streams from now, and
+	// reconnects before the first event also resume from now.
+	Timepoint int64
+
+	// IdleTimeout is how long to wait for data or a heartbeat before
+	// treating the connection as dead. Zero means 90 seconds.
+	IdleTimeout time.Duration
+
+	// OnReconnect, if set, is called before each reconnect with the error
+	// that ended the previous connection and the delay before the next.
+	OnReconnect func(err error, wait time.Duration)
+}
+
+// Stream delivers events from resource to handle until ctx is cancelled or
+// handle returns an error. Dropped connections, server errors and rate
+// limiting are retried indefinitely with backoff, resuming after the last
+// delivered timepoint so no event is skipped or repeated. Authentication
+// failures and out-of-range timepoints (HTTP 416) end the stream.
+func (c *StreamClient) Stream(ctx context.Context, resource string, opts StreamOptions, handle StreamHandler) error {
+	if !slices.Contains(StreamResources, resource) {
+		return fmt.Errorf("unknown stream %q (want one of %s)", resource, strings.Join(StreamResources, ", "))
+	}
+	if opts.IdleTimeout <= 0 {
+		opts.IdleTimeout = defaultStreamIdleTimeout
+	}
+
+	next := opts.Timepoint
+	failures := 0
+	for {
+		delivered, err := c.connect(ctx, resource, next, opts.IdleTimeout, func(e StreamEvent) error {
+			if err := handle(e); err != nil {
+				return err
+			}
+			next = e.Event.Timepoint + 1
+			return nil
+		})
+		if errors.Is(err, ErrStopStream) {
+			return nil
+		}
+		var he handlerError
+		if errors.As(err, &he) {
+			return he.err
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !retryableStreamError(err) {
+			return err
+		}
+		if delivered {
+			failures = 0
+		}
+		wait := min(c.opts.retry.Backoff(failures), maxStreamBackoff)
+		failures++
+		if opts.OnReconnect != nil {
+			opts.OnReconnect(err, wait)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// errStreamEnded reports that the server closed a stream cleanly.
+var errStreamEnded = errors.New("stream closed by server")
+
+// errStreamIdle reports that neither data nor heartbeats arrived in time.
+var errStreamIdle = errors.New("stream idle timeout")
+
+// handlerError marks errors returned by the caller's handler, which end the
+// stream rather than triggering a reconnect.
+type handlerError struct{ err error }
+
+func (e handlerError) Error() string { return e.err.Error() }
+func (e handlerError) Unwrap() error { return e.err }
+
+func retryableStreamError(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+	return true
+}
+
+// connect opens one connection and decodes events until it ends. It reports
+// whether any event was delivered.
+func (c *StreamClient) connect(ctx context.Context, resource string, timepoint int64, idle time.Duration, handle StreamHandler) (bool, error) {
+	connCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	idleTimer := time.AfterFunc(idle, cancel)
+	defer idleTimer.Stop()
+
+	u := c.baseURL + "/" + resource
+	if timepoint > 0 {
+		u += "?timepoint=" + strconv.FormatInt(timepoint, 10)
+	}
+	req, err := http.NewRequestWithContext(connCtx, http.MethodGet, u, nil)
+	if err != nil {
+		return false, fmt.Errorf("create request: %w", err)
+	}
+	req.SetBasicAuth(c.apiKey, "")
+	req.Header.Set("Accept", "application/json")
+	c.opts.prepare(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() == nil && connCtx.Err() != nil {
+			return false, errStreamIdle
+		}
+		return false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return false, newAPIError(resp.StatusCode, body)
+	}
+
+	delivered := false
+	r := bufio.NewReader(resp.Body)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 {
+			idleTimer.Reset(idle)
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			var e StreamEvent
+			if jerr := json.Unmarshal(line, &e); jerr != nil {
+				return delivered, fmt.Errorf("decode event: %w", jerr)
+			}
+			if herr := handle(e); herr != nil {
+				return delivered, handlerError{herr}
+			}
+			delivered = true
+		}
+		if err == io.EOF {
+			return delivered, errStreamEnded
+		}
+		if err != nil {
+			if ctx.Err() == nil && connCtx.Err() != nil {
+				return delivered, errStreamIdle
+			}
+			return delivered, fmt.Errorf("read stream: %w", err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package chapi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// noBackoff reconnects immediately so tests run fast.
+var noBackoff = chapi.WithRetryPolicy(chapi.RetryPolicy{
+	MaxAttempts: 1,
+	Backoff:     func(int) time.Duration { return 0 },
+})
+
+func streamEvent(tp int64, number string) string {
+	return fmt.Sprintf(`{"resource_kind":"company-profile","resource_uri":"/company/%s","resource_id":"%s","data":{"company_number":"%s"},"event":{"timepoint":%d,"published_at":"2024-11-05T09:00:00","type":"changed"}}`+"\n", number, number, number, tp)
+}
+
+func TestStream_DecodesEvents(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/companies" || r.URL.Query().Get("timepoint") != "100" {
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+		if user, _, ok := r.BasicAuth(); !ok || user != "stream-key" {
+			t.Errorf("BasicAuth user = %q", user)
+		}
+		fmt.Fprint(w, streamEvent(100, "00445790"))
+		fmt.Fprint(w, "\n\n") // heartbeats
+		fmt.Fprint(w, streamEvent(101, "12987654"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer srv.Close()
+
+	client := chapi.NewStreamClientWithBaseURL("stream-key", srv.URL)
+	var got []chapi.StreamEvent
+	err := client.Stream(context.Background(), "companies", chapi.StreamOptions{Timepoint: 100}, func(e chapi.StreamEvent) error {
+		got = append(got, e)
+		if len(got) == 2 {
+			return chapi.ErrStopStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	if got[0].Event.Timepoint != 100 || got[1].CompanyNumber() != "12987654" || got[1].Event.Type != "changed" {
+		t.Errorf("events = %+v", got)
+	}
+}
+
+func TestStream_ResumesAfterDisconnect(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch conns.Add(1) {
+		case 1:
+			fmt.Fprint(w, streamEvent(7, "00445790"))
+			fmt.Fprint(w, streamEvent(8, "00445790"))
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			if tp := r.URL.Query().Get("timepoint"); tp != "9" {
+				t.Errorf("reconnect timepoint = %q, want 9", tp)
+			}
+			fmt.Fprint(w, streamEvent(9, "00445790"))
+		}
+	}))
+	defer srv.Close()
+
+	client := chapi.NewStreamClientWithBaseURL("stream-key", srv.URL, noBackoff)
+	var tps []int64
+	var reconnects int
+	opts := chapi.StreamOptions{OnReconnect: func(error, time.Duration) { reconnects++ }}
+	err := client.Stream(context.Background(), "filings", opts, func(e chapi.StreamEvent) error {
+		tps = append(tps, e.Event.Timepoint)
+		if len(tps) == 3 {
+			return chapi.ErrStopStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	if fmt.Sprint(tps) != "[7 8 9]" || reconnects != 2 {
+		t.Errorf("timepoints = %v, reconnects = %d", tps, reconnects)
+	}
+}
+
+func TestStream_IdleTimeoutReconnects(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if conns.Add(1) == 1 {
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprint(w, streamEvent(1, "00445790"))
+	}))
+	defer srv.Close()
+
+	client := chapi.NewStreamClientWithBaseURL("stream-key", srv.URL, noBackoff)
+	opts := chapi.StreamOptions{IdleTimeout: 50 * time.Millisecond}
+	err := client.Stream(context.Background(), "charges", opts, func(chapi.StreamEvent) error {
+		return chapi.ErrStopStream
+	})
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	if n := conns.Load(); n != 2 {
+		t.Errorf("connections = %d, want 2", n)
+	}
+}
+
+func TestStream_FatalErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   error
+	}{
+		{http.StatusUnauthorized, chapi.ErrUnauthorized},
+		{http.StatusRequestedRangeNotSatisfiable, nil},
+	}
+	for _, tt := range tests {
+		var conns atomic.Int32
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			conns.Add(1)
+			w.WriteHeader(tt.status)
+		}))
+		client := chapi.NewStreamClientWithBaseURL("stream-key", srv.URL, noBackoff)
+		err := client.Stream(context.Background(), "officers", chapi.StreamOptions{Timepoint: 1}, func(chapi.StreamEvent) error { return nil })
+		srv.Close()
+
+		var apiErr *chapi.APIError
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != tt.status {
+			t.Errorf("HTTP %d: err = %v", tt.status, err)
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("HTTP %d: err = %v, want %v", tt.status, err, tt.want)
+		}
+		if n := conns.Load(); n != 1 {
+			t.Errorf("HTTP %d: connections = %d, want 1 (no retry)", tt.status, n)
+		}
+	}
+}
+
+func TestStream_HandlerError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, streamEvent(1, "00445790"))
+	}))
+	defer srv.Close()
+
+	boom := errors.New("sink full")
+	client := chapi.NewStreamClientWithBaseURL("stream-key", srv.URL, noBackoff)
+	err := client.Stream(context.Background(), "companies", chapi.StreamOptions{}, func(chapi.StreamEvent) error { return boom })
+	if err != boom {
+		t.Fatalf("Stream() error = %v, want the handler's error", err)
+	}
+}
+
+func TestStream_UnknownResource(t *testing.T) {
+	client := chapi.NewStreamClientWithBaseURL("stream-key", "http://127.0.0.1:0")
+	if err := client.Stream(context.Background(), "widgets", chapi.StreamOptions{}, nil); err == nil {
+		t.Fatal("Stream(widgets) succeeded")
+	}
+}
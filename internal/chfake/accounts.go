@@ -0,0 +1,167 @@
+package chfake
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Accounts is the financial data behind an accounts filing. The fake serves
+// it as an inline XBRL (iXBRL) document alongside the filing's PDF, tagging
+// the prior year from the company's previous Accounts entry as real
+// accounts do.
+type Accounts struct {
+	TransactionID string             `json:"transaction_id"`
+	PeriodStart   string             `json:"period_start"`
+	PeriodEnd     string             `json:"period_end"`
+	Period        map[string]float64 `json:"period,omitempty"`
+	Instant       map[string]float64 `json:"instant,omitempty"`
+	Employees     int                `json:"employees,omitempty"`
+}
+
+// accounts returns the accounts filed in transactionID, and the company
+// they belong to.
+func (s *Server) accounts(transactionID string) (*Company, *Accounts, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.numbers {
+		c := s.companies[n]
+		for i := range c.Accounts {
+			if c.Accounts[i].TransactionID == transactionID {
+				return c, &c.Accounts[i], true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// priorAccounts returns the company's accounts for the period immediately
+// before a, if any.
+func priorAccounts(c *Company, a *Accounts) *Accounts {
+	var prior *Accounts
+	for i := range c.Accounts {
+		p := &c.Accounts[i]
+		if p.PeriodEnd < a.PeriodStart && (prior == nil || p.PeriodEnd > prior.PeriodEnd) {
+			prior = p
+		}
+	}
+	return prior
+}
+
+// accountsDocument renders a as a minimal iXBRL document using the FRS 102
+// core and business taxonomies. Concept names in a.Period and a.Instant are
+// used as given.
+func accountsDocument(c *Company, a *Accounts) []byte {
+	number := c.Profile.CompanyNumber
+	periods := []*Accounts{a}
+	if p := priorAccounts(c, a); p != nil {
+		periods = append(periods, p)
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>
+<html xmlns="http://www.w3.org/1999/xhtml" xmlns:ix="http://www.xbrl.org/2013/inlineXBRL" xmlns:ixt2="http://www.xbrl.org/inlineXBRL/transformation/2011-07-31" xmlns:xbrli="http://www.xbrl.org/2003/instance" xmlns:iso4217="http://www.xbrl.org/2003/iso4217" xmlns:core="http://xbrl.frc.org.uk/fr/2022-01-01/core" xmlns:bus="http://xbrl.frc.org.uk/cd/2022-01-01/business">
+<head><title>`)
+	b.WriteString(html.EscapeString(c.Profile.CompanyName))
+	b.WriteString(" - Annual accounts</title></head>\n<body>\n<div style=\"display:none\"><ix:header><ix:hidden>\n")
+	fmt.Fprintf(&b, "<ix:nonNumeric name=\"bus:EntityCurrentLegalOrRegisteredName\" contextRef=\"d0\">%s</ix:nonNumeric>\n", html.EscapeString(c.Profile.CompanyName))
+	fmt.Fprintf(&b, "<ix:nonNumeric name=\"bus:UKCompaniesHouseRegisteredNumber\" contextRef=\"d0\">%s</ix:nonNumeric>\n", number)
+	b.WriteString("</ix:hidden><ix:resources>\n")
+	for i, p := range periods {
+		fmt.Fprintf(&b, "<xbrli:context id=\"d%d\"><xbrli:entity><xbrli:identifier scheme=\"http://www.companieshouse.gov.uk/\">%s</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:startDate>%s</xbrli:startDate><xbrli:endDate>%s</xbrli:endDate></xbrli:period></xbrli:context>\n", i, number, p.PeriodStart, p.PeriodEnd)
+		fmt.Fprintf(&b, "<xbrli:context id=\"i%d\"><xbrli:entity><xbrli:identifier scheme=\"http://www.companieshouse.gov.uk/\">%s</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:instant>%s</xbrli:instant></xbrli:period></xbrli:context>\n", i, number, p.PeriodEnd)
+	}
+	b.WriteString("<xbrli:unit id=\"GBP\"><xbrli:measure>iso4217:GBP</xbrli:measure></xbrli:unit>\n<xbrli:unit id=\"pure\"><xbrli:measure>xbrli:pure</xbrli:measure></xbrli:unit>\n")
+	b.WriteString("</ix:resources></ix:header></div>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<p>Registered number %s</p>\n<p>Accounts for the period ended <ix:nonNumeric name=\"bus:EndDateForPeriodCoveredByReport\" contextRef=\"d0\">%s</ix:nonNumeric></p>\n",
+		html.EscapeString(c.Profile.CompanyName), number, a.PeriodEnd)
+
+	b.WriteString("<table>\n<tr><th></th>")
+	for _, p := range periods {
+		fmt.Fprintf(&b, "<th>%s<br/>&#163;</th>", p.PeriodEnd[:4])
+	}
+	b.WriteString("</tr>\n")
+	writeRows := func(kind string, values func(*Accounts) map[string]float64) {
+		for _, concept := range conceptNames(periods, values) {
+			fmt.Fprintf(&b, "<tr><td>%s</td>", html.EscapeString(concept))
+			for i, p := range periods {
+				v, ok := values(p)[concept]
+				if !ok {
+					b.WriteString("<td>-</td>")
+					continue
+				}
+				fmt.Fprintf(&b, "<td>%s</td>", nonFraction(concept, fmt.Sprintf("%s%d", kind, i), "GBP", v))
+			}
+			b.WriteString("</tr>\n")
+		}
+	}
+	writeRows("d", func(p *Accounts) map[string]float64 { return p.Period })
+	writeRows("i", func(p *Accounts) map[string]float64 { return p.Instant })
+	b.WriteString("</table>\n")
+
+	if a.Employees > 0 {
+		b.WriteString("<p>Average number of employees: ")
+		for i, p := range periods {
+			if p.Employees == 0 {
+				continue
+			}
+			if i > 0 {
+				fmt.Fprintf(&b, " (%s: ", p.PeriodEnd[:4])
+			}
+			b.WriteString(nonFraction("core:AverageNumberEmployeesDuringPeriod", fmt.Sprintf("d%d", i), "pure", float64(p.Employees)))
+			if i > 0 {
+				b.WriteString(")")
+			}
+		}
+		b.WriteString("</p>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.Bytes()
+}
+
+// conceptNames returns the concepts reported in any of periods, sorted.
+func conceptNames(periods []*Accounts, values func(*Accounts) map[string]float64) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, p := range periods {
+		for name := range values(p) {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// nonFraction tags v the way accounts software does: whole millions are
+// shown scaled, negatives in brackets with sign="-".
+func nonFraction(concept, contextRef, unit string, v float64) string {
+	attrs := fmt.Sprintf(`name="%s" contextRef="%s" unitRef="%s" format="ixt2:numdotdecimal"`, concept, contextRef, unit)
+	abs, open, close := v, "", ""
+	if v < 0 {
+		abs, open, close = -v, "(", ")"
+		attrs += ` sign="-"`
+	}
+	if abs >= 1e6 && abs == float64(int64(abs/1e6))*1e6 {
+		abs /= 1e6
+		attrs += ` scale="6" decimals="-6"`
+	} else {
+		attrs += ` decimals="0"`
+	}
+	return fmt.Sprintf("%s<ix:nonFraction %s>%s</ix:nonFraction>%s", open, attrs, groupThousands(int64(abs)), close)
+}
+
+func groupThousands(n int64) string {
+	s := strconv.FormatInt(n, 10)
+	var parts []string
+	for len(s) > 3 {
+		parts = append([]string{s[len(s)-3:]}, parts...)
+		s = s[:len(s)-3]
+	}
+	return strings.Join(append([]string{s}, parts...), ",")
+}
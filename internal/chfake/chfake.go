@@ -0,0 +1,883 @@
+package chfake
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+//go:embed fixtures/default.json
+var defaultFixtures []byte
+
+// Company bundles everything the fake server knows about one company.
+type Company struct {
+	Profile        chapi.CompanyProfile      `json:"profile"`
+	Officers       []chapi.Officer           `json:"officers,omitempty"`
+	PSCs           []chapi.PSC               `json:"pscs,omitempty"`
+	PSCStatements  []chapi.PSCStatement      `json:"psc_statements,omitempty"`
+	Filings        []chapi.FilingHistoryItem `json:"filings,omitempty"`
+	Charges        []chapi.Charge            `json:"charges,omitempty"`
+	Insolvency     *chapi.InsolvencyResponse `json:"insolvency,omitempty"`
+	Accounts       []Accounts                `json:"accounts,omitempty"`
+	Registers      *chapi.CompanyRegisters   `json:"registers,omitempty"`
+	Exemptions     *chapi.CompanyExemptions  `json:"exemptions,omitempty"`
+	Establishments []chapi.UKEstablishment   `json:"uk_establishments,omitempty"`
+
+	// Overseas entities keep their register fields, beneficial owners and
+	// managing officers apart from the company-shaped data above.
+	Overseas         *Overseas               `json:"overseas,omitempty"`
+	BeneficialOwners []chapi.BeneficialOwner `json:"beneficial_owners,omitempty"`
+	ManagingOfficers []chapi.ManagingOfficer `json:"managing_officers,omitempty"`
+}
+
+// Dataset is the fixture data served by a Server.
+type Dataset struct {
+	Companies              []Company                            `json:"companies"`
+	DisqualifiedOfficers   []chapi.NaturalDisqualifiedOfficer   `json:"disqualified_officers,omitempty"`
+	DisqualifiedCorporates []chapi.CorporateDisqualifiedOfficer `json:"disqualified_corporates,omitempty"`
+}
+
+// DefaultDataset returns the built-in fixture dataset.
+func DefaultDataset() Dataset {
+	var ds Dataset
+	if err := json.Unmarshal(defaultFixtures, &ds); err != nil {
+		panic(fmt.Sprintf("chfake: invalid default fixtures: %v", err))
+	}
+	return ds
+}
+
+// LoadDataset reads a fixture dataset from a JSON file.
+func LoadDataset(path string) (Dataset, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Dataset{}, fmt.Errorf("read dataset: %w", err)
+	}
+	var ds Dataset
+	if err := json.Unmarshal(b, &ds); err != nil {
+		return Dataset{}, fmt.Errorf("parse dataset %s: %w", path, err)
+	}
+	return ds, nil
+}
+
+// Server is an in-process stand-in for the Companies House public data,
+// filing, Document and Streaming APIs. Any non-empty Authorization header is
+// accepted. It is safe for concurrent use.
+type Server struct {
+	mu           sync.Mutex
+	companies    map[string]*Company
+	numbers      []string
+	transactions map[string]*transaction
+	nextTxn      int
+	mux          *http.ServeMux
+
+	// Disqualified officers keep dataset order, which search results follow.
+	disqualified []chapi.NaturalDisqualifiedOfficer
+	corporates   []chapi.CorporateDisqualifiedOfficer
+}
+
+// transaction is a filing transaction and the filings made within it.
+type transaction struct {
+	chapi.Transaction
+	Address *chapi.RegisteredOfficeAddressFiling
+	Email   *chapi.RegisteredEmailAddressFiling
+}
+
+// New returns a server seeded with ds.
+func New(ds Dataset) *Server {
+	s := &Server{
+		companies:    make(map[string]*Company),
+		transactions: make(map[string]*transaction),
+		mux:          http.NewServeMux(),
+	}
+	for i := range ds.Companies {
+		s.AddCompany(ds.Companies[i])
+	}
+	s.disqualified = ds.DisqualifiedOfficers
+	s.corporates = ds.DisqualifiedCorporates
+	s.routes()
+	return s
+}
+
+// AddCompany adds or replaces a company in the dataset.
+func (s *Server) AddCompany(c Company) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := c.Profile.CompanyNumber
+	if _, ok := s.companies[n]; !ok {
+		s.numbers = append(s.numbers, n)
+		sort.Strings(s.numbers)
+	}
+	s.companies[n] = &c
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("GET /company/{number}", s.handleCompany)
+	s.mux.HandleFunc("GET /company/{number}/registered-office-address", s.handleRegisteredOffice)
+	s.mux.HandleFunc("GET /company/{number}/officers", s.handleOfficers)
+	s.mux.HandleFunc("GET /company/{number}/appointments/{id}", s.handleAppointment)
+	s.mux.HandleFunc("GET /company/{number}/persons-with-significant-control", s.handlePSCs)
+	s.mux.HandleFunc("GET /company/{number}/persons-with-significant-control/{kind}/{id}", s.handlePSC)
+	s.mux.HandleFunc("GET /company/{number}/persons-with-significant-control-statements", s.handlePSCStatements)
+	s.mux.HandleFunc("GET /company/{number}/filing-history", s.handleFilingHistory)
+	s.mux.HandleFunc("GET /company/{number}/filing-history/{transaction}", s.handleFilingItem)
+	s.mux.HandleFunc("GET /company/{number}/charges", s.handleCharges)
+	s.mux.HandleFunc("GET /company/{number}/charges/{id}", s.handleCharge)
+	s.mux.HandleFunc("GET /company/{number}/insolvency", s.handleInsolvency)
+	s.mux.HandleFunc("GET /company/{number}/registers", s.handleRegisters)
+	s.mux.HandleFunc("GET /company/{number}/exemptions", s.handleExemptions)
+	s.mux.HandleFunc("GET /company/{number}/uk-establishments", s.handleUKEstablishments)
+	s.mux.HandleFunc("GET /search", s.handleSearch)
+	s.mux.HandleFunc("GET /search/companies", s.handleSearchCompanies)
+	s.mux.HandleFunc("GET /search/officers", s.handleSearchOfficers)
+	s.mux.HandleFunc("GET /advanced-search/companies", s.handleAdvancedSearch)
+	s.mux.HandleFunc("GET /alphabetical-search/companies", s.handleAlphabeticalSearch)
+	s.mux.HandleFunc("GET /dissolved-search/companies", s.handleDissolvedSearch)
+	s.mux.HandleFunc("GET /officers/{id}/appointments", s.handleOfficerAppointments)
+	s.mux.HandleFunc("GET /search/disqualified-officers", s.handleSearchDisqualified)
+	s.mux.HandleFunc("GET /disqualified-officers/natural/{id}", s.handleNaturalDisqualified)
+	s.mux.HandleFunc("GET /disqualified-officers/corporate/{id}", s.handleCorporateDisqualified)
+
+	s.mux.HandleFunc("GET /document/{id}", s.handleDocumentMetadata)
+	s.mux.HandleFunc("GET /document/{id}/content", s.handleDocumentContent)
+	s.mux.HandleFunc("GET "+documentStoragePath+"{id}", s.handleDocumentStorage)
+
+	s.mux.HandleFunc("GET "+StreamPath+"/{resource}", s.handleStream)
+
+	s.mux.HandleFunc("POST /transactions", s.handleCreateTransaction)
+	s.mux.HandleFunc("GET /transactions/{id}", s.handleGetTransaction)
+	s.mux.HandleFunc("PUT /transactions/{id}", s.handleUpdateTransaction)
+	s.mux.HandleFunc("POST /transactions/{id}/registered-office-address", s.handleFileAddress)
+	s.mux.HandleFunc("GET /transactions/{id}/registered-office-address/validation-status", s.handleAddressValidation)
+	s.mux.HandleFunc("POST /transactions/{id}/registered-email-address", s.handleFileEmail)
+}
+
+// ServeHTTP implements http.Handler.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" && !strings.HasPrefix(r.URL.Path, documentStoragePath) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{
+			"error": "Invalid Authorization",
+			"type":  "ch:service",
+		})
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+// company looks up the company named in the request path, writing a 404 if
+// it is unknown.
+func (s *Server) company(w http.ResponseWriter, r *http.Request) (*Company, bool) {
+	s.mu.Lock()
+	c, ok := s.companies[r.PathValue("number")]
+	s.mu.Unlock()
+	if !ok {
+		notFound(w, "company-profile-not-found")
+	}
+	return c, ok
+}
+
+func (s *Server) handleCompany(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	if c.Overseas != nil {
+		writeJSON(w, http.StatusOK, c.Overseas.entity(c.Profile))
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Profile)
+}
+
+func (s *Server) handleRegisteredOffice(w http.ResponseWriter, r *http.Request) {
+	if c, ok := s.company(w, r); ok {
+		writeJSON(w, http.StatusOK, c.Profile.RegisteredOffice)
+	}
+}
+
+func (s *Server) handleOfficers(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	start, size := pageParams(r, 35)
+	if len(c.ManagingOfficers) > 0 {
+		writeManagingOfficers(w, c.ManagingOfficers, start, size)
+		return
+	}
+	resigned := 0
+	for _, o := range c.Officers {
+		if o.ResignedOn != "" {
+			resigned++
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.OfficerList{
+		TotalResults:  len(c.Officers),
+		ActiveCount:   len(c.Officers) - resigned,
+		ResignedCount: resigned,
+		Items:         page(c.Officers, start, size),
+		StartIndex:    start,
+		ItemsPerPage:  size,
+	})
+}
+
+func (s *Server) handleAppointment(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	for _, o := range c.Officers {
+		if o.AppointmentID() == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, o)
+			return
+		}
+	}
+	notFound(w, "appointment-not-found")
+}
+
+func (s *Server) handlePSCs(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	start, size := pageParams(r, 25)
+	if len(c.BeneficialOwners) > 0 {
+		writeBeneficialOwners(w, c.BeneficialOwners, start, size)
+		return
+	}
+	ceased := 0
+	for _, p := range c.PSCs {
+		if p.CeasedOn != "" {
+			ceased++
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.PSCList{
+		TotalResults: len(c.PSCs),
+		ActiveCount:  len(c.PSCs) - ceased,
+		CeasedCount:  ceased,
+		Items:        page(c.PSCs, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+// handlePSC serves a PSC detail record. The flattened list record is
+// returned as is; it carries every field the typed detail responses decode.
+func (s *Server) handlePSC(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	for _, p := range c.PSCs {
+		_, kind, id, ok := chapi.ParsePSCLink(p.Links["self"])
+		if ok && kind == r.PathValue("kind") && id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, p)
+			return
+		}
+	}
+	for _, b := range c.BeneficialOwners {
+		_, kind, id, ok := chapi.ParsePSCLink(b.Links["self"])
+		if ok && kind == r.PathValue("kind") && id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, b)
+			return
+		}
+	}
+	notFound(w, "psc-not-found")
+}
+
+func (s *Server) handlePSCStatements(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	start, size := pageParams(r, 25)
+	ceased := 0
+	for _, st := range c.PSCStatements {
+		if st.CeasedOn != "" {
+			ceased++
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.PSCStatementList{
+		TotalResults: len(c.PSCStatements),
+		ActiveCount:  len(c.PSCStatements) - ceased,
+		CeasedCount:  ceased,
+		Items:        page(c.PSCStatements, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+func (s *Server) handleFilingHistory(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	start, size := pageParams(r, 25)
+	filings := c.Filings
+	if cat := r.URL.Query().Get("category"); cat != "" {
+		filings = nil
+		for _, f := range c.Filings {
+			if f.Category == cat {
+				filings = append(filings, f)
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.FilingHistoryList{
+		TotalCount:   len(filings),
+		Items:        page(filings, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+func (s *Server) handleFilingItem(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	for _, f := range c.Filings {
+		if f.TransactionID == r.PathValue("transaction") {
+			writeJSON(w, http.StatusOK, f)
+			return
+		}
+	}
+	notFound(w, "filing-history-item-not-found")
+}
+
+func (s *Server) handleCharges(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	start, size := pageParams(r, 25)
+	satisfied, part := 0, 0
+	for _, ch := range c.Charges {
+		switch ch.Status {
+		case "fully-satisfied", "satisfied":
+			satisfied++
+		case "part-satisfied":
+			part++
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.ChargeList{
+		TotalCount:         len(c.Charges),
+		Items:              page(c.Charges, start, size),
+		SatisfiedCount:     satisfied,
+		PartSatisfiedCount: part,
+		UnfilteredCount:    len(c.Charges),
+	})
+}
+
+func (s *Server) handleCharge(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	for _, ch := range c.Charges {
+		if chapi.ChargeIDFromLink(ch.Links["self"]) == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, ch)
+			return
+		}
+	}
+	notFound(w, "charge-not-found")
+}
+
+func (s *Server) handleInsolvency(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	if c.Insolvency == nil {
+		notFound(w, "company-insolvencies-not-found")
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Insolvency)
+}
+
+func (s *Server) handleRegisters(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	if c.Registers == nil {
+		notFound(w, "company-registers-not-found")
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Registers)
+}
+
+func (s *Server) handleExemptions(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	if c.Exemptions == nil {
+		notFound(w, "company-exemptions-not-found")
+		return
+	}
+	writeJSON(w, http.StatusOK, c.Exemptions)
+}
+
+func (s *Server) handleUKEstablishments(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.company(w, r)
+	if !ok {
+		return
+	}
+	items := c.Establishments
+	if items == nil {
+		items = []chapi.UKEstablishment{}
+	}
+	writeJSON(w, http.StatusOK, chapi.UKEstablishmentList{
+		Kind:  "related-companies",
+		Items: items,
+		Links: map[string]string{"self": "/company/" + c.Profile.CompanyNumber + "/uk-establishments"},
+	})
+}
+
+func (s *Server) handleSearchCompanies(w http.ResponseWriter, r *http.Request) {
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	start, size := pageParams(r, 20)
+
+	s.mu.Lock()
+	var matches []chapi.CompanyProfile
+	for _, n := range s.numbers {
+		p := s.companies[n].Profile
+		if strings.Contains(strings.ToLower(p.CompanyName), q) || strings.EqualFold(p.CompanyNumber, q) {
+			matches = append(matches, p)
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, chapi.SearchResult{
+		TotalResults: len(matches),
+		Items:        page(matches, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+func (s *Server) handleSearchOfficers(w http.ResponseWriter, r *http.Request) {
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	start, size := pageParams(r, 20)
+
+	s.mu.Lock()
+	var matches []chapi.Officer
+	for _, n := range s.numbers {
+		for _, o := range s.companies[n].Officers {
+			if strings.Contains(strings.ToLower(o.Name), q) {
+				matches = append(matches, o)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, chapi.OfficerSearchResult{
+		TotalResults: len(matches),
+		Items:        page(matches, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+// handleOfficerAppointments gathers every company officer record whose
+// officer.appointments link names the requested officer.
+func (s *Server) handleOfficerAppointments(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	start, size := pageParams(r, 35)
+
+	s.mu.Lock()
+	var items []chapi.OfficerAppointment
+	for _, n := range s.numbers {
+		c := s.companies[n]
+		for _, o := range c.Officers {
+			if o.OfficerID() != id {
+				continue
+			}
+			items = append(items, chapi.OfficerAppointment{
+				Name: o.Name,
+				AppointedTo: chapi.AppointedTo{
+					CompanyName:   c.Profile.CompanyName,
+					CompanyNumber: c.Profile.CompanyNumber,
+					CompanyStatus: c.Profile.CompanyStatus,
+				},
+				OfficerRole:        o.OfficerRole,
+				AppointedOn:        o.AppointedOn,
+				ResignedOn:         o.ResignedOn,
+				Occupation:         o.Occupation,
+				Nationality:        o.Nationality,
+				CountryOfResidence: o.CountryOfResidence,
+				Address:            o.Address,
+				Links:              map[string]string{"company": "/company/" + n},
+			})
+		}
+	}
+	s.mu.Unlock()
+
+	if len(items) == 0 {
+		notFound(w, "appointments-not-found")
+		return
+	}
+	writeJSON(w, http.StatusOK, chapi.OfficerAppointmentList{
+		Name:         items[0].Name,
+		TotalResults: len(items),
+		Items:        page(items, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+func (s *Server) handleSearchDisqualified(w http.ResponseWriter, r *http.Request) {
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	start, size := pageParams(r, 20)
+
+	var matches []chapi.DisqualifiedOfficerSearchItem
+	add := func(title, dob string, ds []chapi.Disqualification, links map[string]string) {
+		if !strings.Contains(strings.ToLower(title), q) {
+			return
+		}
+		item := chapi.DisqualifiedOfficerSearchItem{
+			Kind:  "searchresults#disqualified-officer",
+			Title: title,
+			Links: map[string]string{"self": links["self"]},
+		}
+		if dob != "" {
+			item.DateOfBirth = dob
+			item.Description = "Born on " + dob
+		}
+		if len(ds) > 0 {
+			item.Address = ds[0].Address.RegisteredOffice
+		}
+		matches = append(matches, item)
+	}
+	for _, o := range s.disqualified {
+		add(o.Name(), o.DateOfBirth, o.Disqualifications, o.Links)
+	}
+	for _, o := range s.corporates {
+		add(o.Name, "", o.Disqualifications, o.Links)
+	}
+
+	writeJSON(w, http.StatusOK, chapi.DisqualifiedOfficerSearchResult{
+		TotalResults: len(matches),
+		Items:        page(matches, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+func (s *Server) handleNaturalDisqualified(w http.ResponseWriter, r *http.Request) {
+	for _, o := range s.disqualified {
+		if _, id, _ := chapi.ParseDisqualifiedOfficerLink(o.Links["self"]); id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, o)
+			return
+		}
+	}
+	notFound(w, "disqualified-officer-not-found")
+}
+
+func (s *Server) handleCorporateDisqualified(w http.ResponseWriter, r *http.Request) {
+	for _, o := range s.corporates {
+		if _, id, _ := chapi.ParseDisqualifiedOfficerLink(o.Links["self"]); id == r.PathValue("id") {
+			writeJSON(w, http.StatusOK, o)
+			return
+		}
+	}
+	notFound(w, "disqualified-officer-not-found")
+}
+
+// documentStoragePath stands in for the pre-signed storage URLs the Document
+// API redirects content requests to.
+const documentStoragePath = "/document-storage/"
+
+// document finds the filing whose document_metadata link names id.
+func (s *Server) document(id string) (chapi.FilingHistoryItem, string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, n := range s.numbers {
+		for _, f := range s.companies[n].Filings {
+			if chapi.DocumentIDFromLink(f.Links["document_metadata"]) == id {
+				return f, n, true
+			}
+		}
+	}
+	return chapi.FilingHistoryItem{}, "", false
+}
+
+// documentBody returns the synthetic content served for a document.
+func documentBody(id string) []byte {
+	return []byte(fmt.Sprintf("%%PDF-1.4\n%% chfake document %s\n%%%%EOF\n", id))
+}
+
+func (s *Server) handleDocumentMetadata(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	f, number, ok := s.document(id)
+	if !ok {
+		notFound(w, "document-not-found")
+		return
+	}
+	resources := map[string]chapi.DocumentResource{
+		chapi.ContentTypePDF: {ContentLength: int64(len(documentBody(id)))},
+	}
+	if c, a, ok := s.accounts(f.TransactionID); ok {
+		resources[chapi.ContentTypeXHTML] = chapi.DocumentResource{ContentLength: int64(len(accountsDocument(c, a)))}
+	}
+	writeJSON(w, http.StatusOK, chapi.DocumentMetadata{
+		CompanyNumber:   number,
+		Barcode:         f.Barcode,
+		Category:        f.Category,
+		SignificantDate: f.Date,
+		Pages:           1,
+		Resources:       resources,
+		Links: chapi.DocumentLinks{
+			Self:     "/document/" + id,
+			Document: "/document/" + id + "/content",
+		},
+	})
+}
+
+func (s *Server) handleDocumentContent(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	f, _, ok := s.document(id)
+	if !ok {
+		notFound(w, "document-not-found")
+		return
+	}
+	switch accept := r.Header.Get("Accept"); {
+	case accept == chapi.ContentTypePDF:
+		http.Redirect(w, r, documentStoragePath+id, http.StatusFound)
+	case accept == chapi.ContentTypeXHTML && s.hasAccounts(f.TransactionID):
+		http.Redirect(w, r, documentStoragePath+id+"?format=xhtml", http.StatusFound)
+	default:
+		writeJSON(w, http.StatusNotAcceptable, map[string]any{
+			"errors": []chapi.ErrorDetail{{Error: "content-type-not-available", Type: "ch:service"}},
+		})
+	}
+}
+
+func (s *Server) hasAccounts(transactionID string) bool {
+	_, _, ok := s.accounts(transactionID)
+	return ok
+}
+
+// handleDocumentStorage serves document content. Like the real storage host
+// it rejects requests that still carry the API key.
+func (s *Server) handleDocumentStorage(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "" {
+		http.Error(w, "only one auth mechanism allowed", http.StatusBadRequest)
+		return
+	}
+	id := r.PathValue("id")
+	f, _, ok := s.document(id)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.URL.Query().Get("format") == "xhtml" {
+		c, a, ok := s.accounts(f.TransactionID)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", chapi.ContentTypeXHTML)
+		w.Write(accountsDocument(c, a))
+		return
+	}
+	w.Header().Set("Content-Type", chapi.ContentTypePDF)
+	w.Write(documentBody(id))
+}
+
+func (s *Server) handleCreateTransaction(w http.ResponseWriter, r *http.Request) {
+	var req chapi.Transaction
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		validationError(w, "invalid JSON body", "")
+		return
+	}
+	if req.CompanyNumber == "" {
+		validationError(w, "company_number is required", "company_number")
+		return
+	}
+
+	s.mu.Lock()
+	s.nextTxn++
+	id := fmt.Sprintf("%06d-%06d-%06d", 174000+s.nextTxn, 100000+s.nextTxn, 500000+s.nextTxn)
+	txn := &transaction{Transaction: chapi.Transaction{
+		ID:            id,
+		CompanyNumber: req.CompanyNumber,
+		Description:   req.Description,
+		Reference:     req.Reference,
+		Status:        "open",
+		Links:         map[string]string{"self": "/transactions/" + id},
+	}}
+	s.transactions[id] = txn
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, txn.Transaction)
+}
+
+// transaction looks up the transaction named in the request path, writing a
+// 404 if it is unknown.
+func (s *Server) transaction(w http.ResponseWriter, r *http.Request) (*transaction, bool) {
+	txn, ok := s.transactions[r.PathValue("id")]
+	if !ok {
+		notFound(w, "transaction-not-found")
+	}
+	return txn, ok
+}
+
+func (s *Server) handleGetTransaction(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if txn, ok := s.transaction(w, r); ok {
+		writeJSON(w, http.StatusOK, txn.Transaction)
+	}
+}
+
+func (s *Server) handleUpdateTransaction(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		validationError(w, "invalid JSON body", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn, ok := s.transaction(w, r)
+	if !ok {
+		return
+	}
+	if req.Status == "closed" {
+		if txn.Address == nil && txn.Email == nil {
+			validationError(w, "transaction has no filings to submit", "status")
+			return
+		}
+		if txn.Address != nil {
+			c := s.companies[txn.CompanyNumber]
+			if c != nil {
+				c.Profile.RegisteredOffice = chapi.RegisteredOffice(*txn.Address)
+			}
+		}
+		txn.Status = "closed"
+	}
+	writeJSON(w, http.StatusOK, txn.Transaction)
+}
+
+func (s *Server) handleFileAddress(w http.ResponseWriter, r *http.Request) {
+	var addr chapi.RegisteredOfficeAddressFiling
+	if err := json.NewDecoder(r.Body).Decode(&addr); err != nil {
+		validationError(w, "invalid JSON body", "")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn, ok := s.transaction(w, r)
+	if !ok {
+		return
+	}
+	txn.Address = &addr
+	s.addResource(txn, "registered-office-address")
+	w.WriteHeader(http.StatusCreated)
+}
+
+func (s *Server) handleAddressValidation(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn, ok := s.transaction(w, r)
+	if !ok {
+		return
+	}
+	if txn.Address == nil {
+		notFound(w, "registered-office-address-not-found")
+		return
+	}
+	var errs []string
+	if txn.Address.AddressLine1 == "" {
+		errs = append(errs, "address_line_1 is required")
+	}
+	if txn.Address.Locality == "" {
+		errs = append(errs, "locality is required")
+	}
+	if txn.Address.PostalCode == "" {
+		errs = append(errs, "postal_code is required")
+	}
+	writeJSON(w, http.StatusOK, chapi.ValidationStatus{Valid: len(errs) == 0, Errors: errs})
+}
+
+func (s *Server) handleFileEmail(w http.ResponseWriter, r *http.Request) {
+	var email chapi.RegisteredEmailAddressFiling
+	if err := json.NewDecoder(r.Body).Decode(&email); err != nil {
+		validationError(w, "invalid JSON body", "")
+		return
+	}
+	if !strings.Contains(email.RegisteredEmailAddress, "@") {
+		validationError(w, "registered_email_address is not a valid email address", "registered_email_address")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	txn, ok := s.transaction(w, r)
+	if !ok {
+		return
+	}
+	txn.Email = &email
+	s.addResource(txn, "registered-email-address")
+	w.WriteHeader(http.StatusCreated)
+}
+
+// addResource records a filing against a transaction the way the real API
+// lists it under resources.
+func (s *Server) addResource(txn *transaction, kind string) {
+	if txn.Resources == nil {
+		txn.Resources = make(map[string]any)
+	}
+	link := fmt.Sprintf("/transactions/%s/%s", txn.ID, kind)
+	txn.Resources[link] = map[string]any{"kind": kind}
+}
+
+// pageParams reads items_per_page and start_index, applying the endpoint's
+// default page size and the API's maximum of 100.
+func pageParams(r *http.Request, defaultSize int) (start, size int) {
+	size = defaultSize
+	if v, err := strconv.Atoi(r.URL.Query().Get("items_per_page")); err == nil && v > 0 {
+		size = min(v, 100)
+	}
+	if v, err := strconv.Atoi(r.URL.Query().Get("start_index")); err == nil && v > 0 {
+		start = v
+	}
+	return start, size
+}
+
+func page[T any](items []T, start, size int) []T {
+	if start >= len(items) {
+		return []T{}
+	}
+	return items[start:min(start+size, len(items))]
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func notFound(w http.ResponseWriter, errType string) {
+	writeJSON(w, http.StatusNotFound, map[string]any{
+		"errors": []chapi.ErrorDetail{{Error: errType, Type: "ch:service"}},
+	})
+}
+
+func validationError(w http.ResponseWriter, msg, location string) {
+	d := chapi.ErrorDetail{Error: msg, Type: "ch:validation", Location: location}
+	if location != "" {
+		d.LocationType = "json-path"
+	}
+	writeJSON(w, http.StatusBadRequest, map[string]any{"errors": []chapi.ErrorDetail{d}})
+}
@@ -0,0 +1,630 @@
+package chfake_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/chfake"
+	"github.com/anthonyencodeclub/ch/internal/ixbrl"
+)
+
+func newFake(t *testing.T) (*httptest.Server, *chapi.Client) {
+	t.Helper()
+	srv := httptest.NewServer(chfake.New(chfake.DefaultDataset()))
+	t.Cleanup(srv.Close)
+	return srv, chapi.NewWithBaseURL("test-api-key", srv.URL)
+}
+
+func TestFake_CompanyEndpoints(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	profile, err := client.GetCompany(ctx, "00445790")
+	if err != nil {
+		t.Fatalf("GetCompany() error: %v", err)
+	}
+	if profile.CompanyName != "TESCO PLC" {
+		t.Errorf("CompanyName = %q, want %q", profile.CompanyName, "TESCO PLC")
+	}
+
+	officers, err := client.ListOfficers(ctx, "00445790", 2, 0)
+	if err != nil {
+		t.Fatalf("ListOfficers() error: %v", err)
+	}
+	if officers.TotalResults != 3 || officers.ResignedCount != 1 || len(officers.Items) != 2 {
+		t.Errorf("officers = total %d, resigned %d, page %d; want 3, 1, 2",
+			officers.TotalResults, officers.ResignedCount, len(officers.Items))
+	}
+
+	all, err := client.ListAllOfficers(ctx, "00445790", 0)
+	if err != nil {
+		t.Fatalf("ListAllOfficers() error: %v", err)
+	}
+	if len(all.Items) != 3 {
+		t.Errorf("ListAllOfficers() items = %d, want 3", len(all.Items))
+	}
+
+	filings, err := client.ListFilingHistory(ctx, "00445790", "accounts", 0, 0)
+	if err != nil {
+		t.Fatalf("ListFilingHistory() error: %v", err)
+	}
+	if filings.TotalCount != 3 {
+		t.Errorf("accounts filings = %d, want 3", filings.TotalCount)
+	}
+
+	insolvency, err := client.GetInsolvency(ctx, "04567321")
+	if err != nil {
+		t.Fatalf("GetInsolvency() error: %v", err)
+	}
+	if len(insolvency.Cases) != 1 || insolvency.Cases[0].Type != "creditors-voluntary-liquidation" {
+		t.Errorf("insolvency cases = %+v", insolvency.Cases)
+	}
+}
+
+func TestFake_KYCEndpoints(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	registers, err := client.GetRegisters(ctx, "12987654")
+	if err != nil {
+		t.Fatalf("GetRegisters() error: %v", err)
+	}
+	if loc, ok := registers.Registers["persons_with_significant_control"].Current(); !ok || loc.MovedOn != "2023-05-15" {
+		t.Errorf("PSC register location = %+v, %v", loc, ok)
+	}
+	if _, err := client.GetRegisters(ctx, "00445790"); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("GetRegisters(no moves) err = %v, want ErrNotFound", err)
+	}
+
+	exemptions, err := client.GetExemptions(ctx, "00445790")
+	if err != nil {
+		t.Fatalf("GetExemptions() error: %v", err)
+	}
+	if !exemptions.Exemptions["psc_exempt_as_trading_on_regulated_market"].Active() {
+		t.Errorf("exemptions = %+v, want an active regulated market exemption", exemptions.Exemptions)
+	}
+
+	establishments, err := client.ListUKEstablishments(ctx, "FC031234")
+	if err != nil {
+		t.Fatalf("ListUKEstablishments() error: %v", err)
+	}
+	if len(establishments.Items) != 1 || establishments.Items[0].CompanyNumber != "BR015678" {
+		t.Errorf("establishments = %+v", establishments.Items)
+	}
+}
+
+func TestFake_OverseasEntity(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	entity, err := client.GetOverseasEntity(ctx, "OE012345")
+	if err != nil {
+		t.Fatalf("GetOverseasEntity() error: %v", err)
+	}
+	if !entity.IsOverseasEntity() || entity.ForeignCompanyDetails == nil || entity.ServiceAddress == nil {
+		t.Errorf("entity = %+v, want ROE fields", entity)
+	}
+
+	owners, err := client.ListAllBeneficialOwners(ctx, "OE012345", 0)
+	if err != nil {
+		t.Fatalf("ListAllBeneficialOwners() error: %v", err)
+	}
+	if owners.TotalResults != 4 || owners.CeasedCount != 1 {
+		t.Errorf("owners = total %d, ceased %d; want 4, 1", owners.TotalResults, owners.CeasedCount)
+	}
+	_, kind, id, _ := chapi.ParsePSCLink(owners.Items[1].Links["self"])
+	corporate, err := client.GetCorporateEntityBeneficialOwner(ctx, "OE012345", id)
+	if err != nil || kind != chapi.PSCKindCorporateEntityBeneficialOwner {
+		t.Fatalf("GetCorporateEntityBeneficialOwner(%s) = %v, kind %q", id, err, kind)
+	}
+	if corporate.PrincipalOfficeAddress == nil {
+		t.Error("corporate beneficial owner has no principal office address")
+	}
+
+	officers, err := client.ListManagingOfficers(ctx, "OE012345", 0, 0)
+	if err != nil {
+		t.Fatalf("ListManagingOfficers() error: %v", err)
+	}
+	if len(officers.Items) != 2 || !officers.Items[1].IsCorporate() {
+		t.Errorf("managing officers = %+v", officers.Items)
+	}
+}
+
+func TestFake_OwnershipChain(t *testing.T) {
+	_, client := newFake(t)
+
+	root, err := client.ResolveOwnershipChain(context.Background(), "12987654", 5)
+	if err != nil {
+		t.Fatalf("ResolveOwnershipChain() error: %v", err)
+	}
+	if len(root.Owners) != 1 {
+		t.Fatalf("root owners = %d, want 1 (the ceased individual is skipped)", len(root.Owners))
+	}
+	holdings := root.Owners[0]
+	if holdings.CompanyNumber != "13876501" || len(holdings.Owners) != 2 {
+		t.Fatalf("holdings = %+v", holdings)
+	}
+	okafor, ventures := holdings.Owners[0], holdings.Owners[1]
+	if okafor.Stop != chapi.ChainStopIndividual || okafor.EffectiveOwnership.String() != "37.5-75%" {
+		t.Errorf("okafor = %+v, effective %v", okafor, okafor.EffectiveOwnership)
+	}
+	stops := map[string]string{}
+	for _, o := range ventures.Owners {
+		stops[o.Name] = o.Stop
+	}
+	if stops["FJORD CAPITAL AS"] != chapi.ChainStopForeign || stops["NORTHWIND HOLDINGS LIMITED"] != chapi.ChainStopCycle {
+		t.Errorf("ventures owners stops = %v", stops)
+	}
+
+	root, err = client.ResolveOwnershipChain(context.Background(), "12987654", 1)
+	if err != nil {
+		t.Fatalf("ResolveOwnershipChain() error: %v", err)
+	}
+	if got := root.Owners[0]; got.Stop != chapi.ChainStopDepth || got.Owners != nil {
+		t.Errorf("depth 1 holdings = %+v, want stopped at the depth limit", got)
+	}
+}
+
+func TestFake_OwnershipChainUnknownCompany(t *testing.T) {
+	fake := chfake.New(chfake.Dataset{})
+	fake.AddCompany(chfake.Company{
+		Profile: chapi.CompanyProfile{CompanyNumber: "SC123456", CompanyName: "THISTLE LTD"},
+		PSCs: []chapi.PSC{{
+			Name:             "MISTYPED HOLDINGS LIMITED",
+			Kind:             "corporate-entity-person-with-significant-control",
+			NaturesOfControl: []string{"ownership-of-shares-75-to-100-percent"},
+			Identification:   &chapi.PSCIdentification{CountryRegistered: "Scotland", RegistrationNumber: "SC999999"},
+		}},
+	})
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	client := chapi.NewWithBaseURL("test-api-key", srv.URL)
+
+	root, err := client.ResolveOwnershipChain(context.Background(), "SC123456", 5)
+	if err != nil {
+		t.Fatalf("ResolveOwnershipChain() error: %v", err)
+	}
+	if len(root.Owners) != 1 {
+		t.Fatalf("root owners = %d, want 1", len(root.Owners))
+	}
+	if got := root.Owners[0]; got.CompanyNumber != "SC999999" || got.Stop != chapi.ChainStopNotFound {
+		t.Errorf("owner = %+v, want SC999999 stopped as not found", got)
+	}
+}
+
+func TestFake_NotFound(t *testing.T) {
+	_, client := newFake(t)
+	_, err := client.GetCompany(context.Background(), "99999999")
+	if !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFake_RequiresAuthorization(t *testing.T) {
+	srv, _ := newFake(t)
+	resp, err := http.Get(srv.URL + "/company/00445790")
+	if err != nil {
+		t.Fatalf("Get() error: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+}
+
+func TestFake_Search(t *testing.T) {
+	_, client := newFake(t)
+	result, err := client.SearchCompanies(context.Background(), "northwind", 0, 0)
+	if err != nil {
+		t.Fatalf("SearchCompanies() error: %v", err)
+	}
+	if result.TotalResults != 3 {
+		t.Errorf("TotalResults = %d, want 3", result.TotalResults)
+	}
+}
+
+func TestFake_SearchAll(t *testing.T) {
+	_, client := newFake(t)
+	result, err := client.SearchAll(context.Background(), "price", 0, 0)
+	if err != nil {
+		t.Fatalf("SearchAll() error: %v", err)
+	}
+	kinds := make(map[string]int)
+	for _, item := range result.Items {
+		kinds[item.ResultKind()]++
+	}
+	if result.TotalResults == 0 || kinds[chapi.SearchKindDisqualifiedOfficer] != 1 {
+		t.Errorf("kinds = %v, want the disqualified officer among the results", kinds)
+	}
+
+	result, err = client.SearchAll(context.Background(), "old mill", 0, 0)
+	if err != nil {
+		t.Fatalf("SearchAll() error: %v", err)
+	}
+	if result.TotalResults != 2 || result.Items[0].CompanyNumber == "" {
+		t.Errorf("old mill results = %+v, want both companies", result.Items)
+	}
+}
+
+func TestFake_AdvancedSearch(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	result, err := client.AdvancedSearchAllCompanies(ctx, chapi.AdvancedSearchParams{
+		Status:           []string{"active"},
+		Type:             []string{"ltd"},
+		SICCodes:         []string{"62020"},
+		Location:         "leeds",
+		IncorporatedFrom: "2020-01-01",
+	}, 0)
+	if err != nil {
+		t.Fatalf("AdvancedSearchAllCompanies() error: %v", err)
+	}
+	if result.Hits != 1 || len(result.Items) != 1 || result.Items[0].CompanyNumber != "12987654" {
+		t.Errorf("result = %+v, want only 12987654", result)
+	}
+
+	result, err = client.AdvancedSearchCompanies(ctx, chapi.AdvancedSearchParams{DissolvedFrom: "2019-01-01", DissolvedTo: "2019-12-31"}, 0, 0)
+	if err != nil {
+		t.Fatalf("AdvancedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 1 || result.Items[0].CompanyNumber != "06123789" {
+		t.Errorf("result = %+v, want only 06123789", result)
+	}
+
+	result, err = client.AdvancedSearchCompanies(ctx, chapi.AdvancedSearchParams{Location: "Atlantis"}, 0, 0)
+	if err != nil {
+		t.Fatalf("AdvancedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 0 {
+		t.Errorf("Hits = %d, want 0 for a search with no matches", result.Hits)
+	}
+}
+
+func TestFake_AlphabeticalSearch(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	result, err := client.AlphabeticalSearchCompanies(ctx, "northwind h", "", "", 2)
+	if err != nil {
+		t.Fatalf("AlphabeticalSearchCompanies() error: %v", err)
+	}
+	if result.TopHit == nil || result.TopHit.CompanyNumber != "13876501" {
+		t.Errorf("TopHit = %+v, want NORTHWIND HOLDINGS LIMITED", result.TopHit)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("Items = %+v, want 2", result.Items)
+	}
+
+	last := result.Items[1].OrderedAlphaKeyWithID
+	next, err := client.AlphabeticalSearchCompanies(ctx, "northwind h", "", last, 2)
+	if err != nil {
+		t.Fatalf("AlphabeticalSearchCompanies(below) error: %v", err)
+	}
+	for _, item := range next.Items {
+		if item.OrderedAlphaKeyWithID <= last {
+			t.Errorf("item %s is not below %s", item.OrderedAlphaKeyWithID, last)
+		}
+		if item.CompanyStatus == "dissolved" {
+			t.Errorf("dissolved company %s on the live register", item.CompanyNumber)
+		}
+	}
+}
+
+func TestFake_DissolvedSearch(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	result, err := client.DissolvedSearchCompanies(ctx, "old mill trading", chapi.DissolvedSearchBestMatch, 0, 0)
+	if err != nil {
+		t.Fatalf("DissolvedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 0 {
+		t.Errorf("best-match Hits = %d, want 0 (the live company is not dissolved)", result.Hits)
+	}
+
+	result, err = client.DissolvedSearchCompanies(ctx, "old mill trading", chapi.DissolvedSearchPreviousName, 0, 0)
+	if err != nil {
+		t.Fatalf("DissolvedSearchCompanies() error: %v", err)
+	}
+	if result.Hits != 1 || result.Items[0].MatchedPreviousCompanyName == nil {
+		t.Errorf("previous-name result = %+v, want 06123789 with a matched name", result)
+	}
+
+	if _, err := client.DissolvedSearchCompanies(ctx, "mill", "fuzzy", 0, 0); !errors.Is(err, chapi.ErrValidation) {
+		t.Errorf("unknown search_type err = %v, want ErrValidation", err)
+	}
+}
+
+func TestFake_AddCompany(t *testing.T) {
+	fake := chfake.New(chfake.Dataset{})
+	fake.AddCompany(chfake.Company{Profile: chapi.CompanyProfile{CompanyNumber: "SC123456", CompanyName: "THISTLE LTD"}})
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	client := chapi.NewWithBaseURL("test-api-key", srv.URL)
+
+	profile, err := client.GetCompany(context.Background(), "SC123456")
+	if err != nil {
+		t.Fatalf("GetCompany() error: %v", err)
+	}
+	if profile.CompanyName != "THISTLE LTD" {
+		t.Errorf("CompanyName = %q, want %q", profile.CompanyName, "THISTLE LTD")
+	}
+}
+
+func TestFake_FilingTransaction(t *testing.T) {
+	srv, client := newFake(t)
+	filing := chapi.NewFilingClientWithBaseURL("token", srv.URL, chapi.WithTimeout(5*time.Second))
+	ctx := context.Background()
+
+	txn, err := filing.CreateTransaction(ctx, "12987654", "Change of registered office address")
+	if err != nil {
+		t.Fatalf("CreateTransaction() error: %v", err)
+	}
+	addr := chapi.RegisteredOfficeAddressFiling{
+		AddressLine1: "1 Wellington Place",
+		Locality:     "Leeds",
+		PostalCode:   "LS1 4AP",
+	}
+	if err := filing.FileRegisteredOfficeAddress(ctx, txn.ID, addr); err != nil {
+		t.Fatalf("FileRegisteredOfficeAddress() error: %v", err)
+	}
+	v, err := filing.GetAddressValidation(ctx, txn.ID)
+	if err != nil {
+		t.Fatalf("GetAddressValidation() error: %v", err)
+	}
+	if !v.Valid {
+		t.Errorf("validation errors: %v", v.Errors)
+	}
+	closed, err := filing.CloseTransaction(ctx, txn.ID)
+	if err != nil {
+		t.Fatalf("CloseTransaction() error: %v", err)
+	}
+	if closed.Status != "closed" {
+		t.Errorf("Status = %q, want closed", closed.Status)
+	}
+
+	office, err := client.GetRegisteredOffice(ctx, "12987654")
+	if err != nil {
+		t.Fatalf("GetRegisteredOffice() error: %v", err)
+	}
+	if office.AddressLine1 != "1 Wellington Place" {
+		t.Errorf("AddressLine1 = %q, want the filed address", office.AddressLine1)
+	}
+}
+
+func TestFake_OfficerAppointments(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	officers, err := client.ListOfficers(ctx, "12987654", 0, 0)
+	if err != nil {
+		t.Fatalf("ListOfficers() error: %v", err)
+	}
+	id := officers.Items[0].OfficerID()
+	if id == "" {
+		t.Fatal("OfficerID() is empty for fixture officer")
+	}
+
+	appts, err := client.ListAllOfficerAppointments(ctx, id, 0)
+	if err != nil {
+		t.Fatalf("ListAllOfficerAppointments() error: %v", err)
+	}
+	if appts.TotalResults != 2 {
+		t.Fatalf("TotalResults = %d, want 2", appts.TotalResults)
+	}
+	if got := appts.Items[1].AppointedTo.CompanyName; got != "NORTHWIND HOLDINGS LIMITED" {
+		t.Errorf("Items[1].AppointedTo.CompanyName = %q, want %q", got, "NORTHWIND HOLDINGS LIMITED")
+	}
+
+	if _, err := client.GetOfficerAppointments(ctx, "unknown", 0, 0); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("unknown officer err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFake_DisqualifiedOfficers(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	result, err := client.SearchDisqualifiedOfficers(ctx, "price", 0, 0)
+	if err != nil {
+		t.Fatalf("SearchDisqualifiedOfficers() error: %v", err)
+	}
+	if result.TotalResults != 1 {
+		t.Fatalf("TotalResults = %d, want 1", result.TotalResults)
+	}
+	kind, id, ok := result.Items[0].OfficerKind()
+	if !ok || kind != "natural" {
+		t.Fatalf("OfficerKind() = %q, %q, %v", kind, id, ok)
+	}
+
+	officer, err := client.GetNaturalDisqualifiedOfficer(ctx, id)
+	if err != nil {
+		t.Fatalf("GetNaturalDisqualifiedOfficer() error: %v", err)
+	}
+	if officer.Surname != "PRICE" || len(officer.PermissionsToAct) != 1 {
+		t.Errorf("officer = %+v", officer)
+	}
+
+	if _, err := client.GetCorporateDisqualifiedOfficer(ctx, id); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("natural ID as corporate err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFake_OfficerAppointment(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	officers, err := client.ListOfficers(ctx, "13876501", 0, 0)
+	if err != nil {
+		t.Fatalf("ListOfficers() error: %v", err)
+	}
+	var appointmentID string
+	for _, o := range officers.Items {
+		if o.Identification != nil {
+			appointmentID = o.AppointmentID()
+		}
+	}
+	if appointmentID == "" {
+		t.Fatal("no corporate officer with an appointment ID in fixtures")
+	}
+
+	o, err := client.GetOfficerAppointment(ctx, "13876501", appointmentID)
+	if err != nil {
+		t.Fatalf("GetOfficerAppointment() error: %v", err)
+	}
+	if o.Identification.RegistrationNumber != "09123456" {
+		t.Errorf("RegistrationNumber = %q, want %q", o.Identification.RegistrationNumber, "09123456")
+	}
+
+	if _, err := client.GetOfficerAppointment(ctx, "00445790", appointmentID); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("appointment at wrong company err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFake_PSCDetailAndStatements(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	pscs, err := client.ListPSCs(ctx, "12987654", 0, 0)
+	if err != nil {
+		t.Fatalf("ListPSCs() error: %v", err)
+	}
+	_, kind, id, ok := chapi.ParsePSCLink(pscs.Items[0].Links["self"])
+	if !ok || kind != chapi.PSCKindCorporateEntity {
+		t.Fatalf("ParsePSCLink() = %q, %q, %v", kind, id, ok)
+	}
+	psc, err := client.GetCorporateEntityPSC(ctx, "12987654", id)
+	if err != nil {
+		t.Fatalf("GetCorporateEntityPSC() error: %v", err)
+	}
+	if psc.Identification == nil || psc.Identification.RegistrationNumber != "13876501" {
+		t.Errorf("Identification = %+v", psc.Identification)
+	}
+	if _, err := client.GetIndividualPSC(ctx, "12987654", id); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("wrong kind err = %v, want ErrNotFound", err)
+	}
+
+	statements, err := client.ListPSCStatements(ctx, "04567321", 0, 0)
+	if err != nil {
+		t.Fatalf("ListPSCStatements() error: %v", err)
+	}
+	if statements.ActiveCount != 1 || statements.Items[0].Statement != "psc-exists-but-not-identified" {
+		t.Errorf("statements = %+v", statements)
+	}
+}
+
+func TestFake_Charge(t *testing.T) {
+	_, client := newFake(t)
+	ctx := context.Background()
+
+	charges, err := client.ListCharges(ctx, "00445790", 0, 0)
+	if err != nil {
+		t.Fatalf("ListCharges() error: %v", err)
+	}
+	id := chapi.ChargeIDFromLink(charges.Items[1].Links["self"])
+	ch, err := client.GetCharge(ctx, "00445790", id)
+	if err != nil {
+		t.Fatalf("GetCharge() error: %v", err)
+	}
+	if ch.Status != "fully-satisfied" || len(ch.Transactions) != 2 {
+		t.Errorf("charge = status %q, %d transactions; want fully-satisfied, 2", ch.Status, len(ch.Transactions))
+	}
+	if _, err := client.GetCharge(ctx, "00445790", "missing"); !errors.Is(err, chapi.ErrNotFound) {
+		t.Errorf("missing charge err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestFake_Documents(t *testing.T) {
+	srv, client := newFake(t)
+	docs := chapi.NewDocumentClientWithBaseURL("test-api-key", srv.URL)
+	ctx := context.Background()
+
+	item, err := client.GetFilingHistoryItem(ctx, "00445790", "MzQyMDk4NjU0M2FkaXF6a2N4")
+	if err != nil {
+		t.Fatalf("GetFilingHistoryItem() error: %v", err)
+	}
+	id := chapi.DocumentIDFromLink(item.Links["document_metadata"])
+	meta, err := docs.GetDocumentMetadata(ctx, id)
+	if err != nil {
+		t.Fatalf("GetDocumentMetadata() error: %v", err)
+	}
+	if meta.CompanyNumber != "00445790" || !meta.HasFormat(chapi.ContentTypePDF) {
+		t.Errorf("metadata = %+v", meta)
+	}
+
+	var buf bytes.Buffer
+	if _, err := docs.DownloadDocument(ctx, id, chapi.ContentTypePDF, &buf); err != nil {
+		t.Fatalf("DownloadDocument() error: %v", err)
+	}
+	if !bytes.HasPrefix(buf.Bytes(), []byte("%PDF-")) {
+		t.Errorf("content = %q, want a PDF", buf.String())
+	}
+
+	if _, err := docs.DownloadDocument(ctx, "2e79205baf813a49", chapi.ContentTypeXHTML, &buf); err == nil {
+		t.Error("DownloadDocument(xhtml) succeeded for a PDF-only document")
+	}
+}
+
+func TestFake_AccountsDocument(t *testing.T) {
+	srv, _ := newFake(t)
+	docs := chapi.NewDocumentClientWithBaseURL("test-api-key", srv.URL)
+	ctx := context.Background()
+
+	meta, err := docs.GetDocumentMetadata(ctx, "tN2sQmR7aV1vZ9k")
+	if err != nil {
+		t.Fatalf("GetDocumentMetadata() error: %v", err)
+	}
+	if _, ok := meta.Resources[chapi.ContentTypeXHTML]; !ok {
+		t.Fatalf("resources = %v, want xhtml for an accounts filing", meta.Resources)
+	}
+
+	var buf bytes.Buffer
+	if _, err := docs.DownloadDocument(ctx, "tN2sQmR7aV1vZ9k", chapi.ContentTypeXHTML, &buf); err != nil {
+		t.Fatalf("DownloadDocument(xhtml) error: %v", err)
+	}
+	doc, err := ixbrl.Parse(&buf)
+	if err != nil {
+		t.Fatalf("ixbrl.Parse() error: %v", err)
+	}
+	if got := doc.EntityName(); got != "TESCO PLC" {
+		t.Errorf("EntityName() = %q", got)
+	}
+	if fig, ok := doc.Figure("turnover", "2024-02-24"); !ok || fig.Value != 68187000000 {
+		t.Errorf("turnover 2024 = %+v, %v", fig, ok)
+	}
+	// The prior year comes from the previous accounts filing.
+	if fig, ok := doc.Figure("profit", "2023-02-25"); !ok || fig.Value != 744000000 {
+		t.Errorf("profit 2023 = %+v, %v", fig, ok)
+	}
+}
+
+func TestFake_Stream(t *testing.T) {
+	srv, _ := newFake(t)
+	stream := chapi.NewStreamClientWithBaseURL("test-api-key", srv.URL+chfake.StreamPath)
+
+	var events []chapi.StreamEvent
+	err := stream.Stream(context.Background(), "persons-with-significant-control", chapi.StreamOptions{Timepoint: 2}, func(e chapi.StreamEvent) error {
+		events = append(events, e)
+		if len(events) == 2 {
+			return chapi.ErrStopStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error: %v", err)
+	}
+	if events[0].Event.Timepoint != 2 || events[1].Event.Timepoint != 3 {
+		t.Errorf("timepoints = %d, %d, want 2, 3", events[0].Event.Timepoint, events[1].Event.Timepoint)
+	}
+	if !strings.HasPrefix(events[0].ResourceKind, "company-psc-") || events[0].CompanyNumber() == "" {
+		t.Errorf("event = %+v", events[0])
+	}
+}
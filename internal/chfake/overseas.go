@@ -0,0 +1,58 @@
+package chfake
+
+import (
+	"net/http"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// Overseas holds the Register of Overseas Entities fields served with an
+// overseas entity's profile.
+type Overseas struct {
+	ForeignCompanyDetails           *chapi.ForeignCompanyDetails `json:"foreign_company_details,omitempty"`
+	ServiceAddress                  *chapi.RegisteredOffice      `json:"service_address,omitempty"`
+	SuperSecureManagingOfficerCount int                          `json:"super_secure_managing_officer_count,omitempty"`
+}
+
+func (o *Overseas) entity(p chapi.CompanyProfile) chapi.OverseasEntity {
+	return chapi.OverseasEntity{
+		CompanyProfile:                  p,
+		ForeignCompanyDetails:           o.ForeignCompanyDetails,
+		ServiceAddress:                  o.ServiceAddress,
+		SuperSecureManagingOfficerCount: o.SuperSecureManagingOfficerCount,
+	}
+}
+
+func writeBeneficialOwners(w http.ResponseWriter, owners []chapi.BeneficialOwner, start, size int) {
+	ceased := 0
+	for _, b := range owners {
+		if !b.Active() {
+			ceased++
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.BeneficialOwnerList{
+		TotalResults: len(owners),
+		ActiveCount:  len(owners) - ceased,
+		CeasedCount:  ceased,
+		Items:        page(owners, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+func writeManagingOfficers(w http.ResponseWriter, officers []chapi.ManagingOfficer, start, size int) {
+	resigned := 0
+	for _, o := range officers {
+		if o.ResignedOn != "" {
+			resigned++
+		}
+	}
+	writeJSON(w, http.StatusOK, chapi.ManagingOfficerList{
+		TotalResults:  len(officers),
+		ActiveCount:   len(officers) - resigned,
+		ResignedCount: resigned,
+		Items:         page(officers, start, size),
+		StartIndex:    start,
+		ItemsPerPage:  size,
+	})
+}
@@ -0,0 +1,412 @@
+package chfake
+
+import (
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// handleAdvancedSearch filters the dataset the way the advanced search
+// endpoint does. Like the real API, a search with no matches is a 404.
+func (s *Server) handleAdvancedSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	start, _ := strconv.Atoi(q.Get("start_index"))
+	size := 20
+	if v, err := strconv.Atoi(q.Get("size")); err == nil && v > 0 {
+		size = min(v, 5000)
+	}
+
+	s.mu.Lock()
+	var matches []chapi.AdvancedSearchItem
+	for _, n := range s.numbers {
+		p := s.companies[n].Profile
+		if advancedMatch(p, q) {
+			matches = append(matches, advancedItem(p))
+		}
+	}
+	s.mu.Unlock()
+
+	if len(matches) == 0 {
+		notFound(w, "no-results-found")
+		return
+	}
+	writeJSON(w, http.StatusOK, chapi.AdvancedSearchResult{
+		Hits:   len(matches),
+		Kind:   "search#advanced-search",
+		TopHit: &matches[0],
+		Items:  page(matches, max(start, 0), size),
+	})
+}
+
+func advancedMatch(p chapi.CompanyProfile, q url.Values) bool {
+	name := strings.ToLower(p.CompanyName)
+	if v := q.Get("company_name_includes"); v != "" && !strings.Contains(name, strings.ToLower(v)) {
+		return false
+	}
+	if v := q.Get("company_name_excludes"); v != "" && strings.Contains(name, strings.ToLower(v)) {
+		return false
+	}
+	if v := q["company_status"]; len(v) > 0 && !slices.Contains(v, p.CompanyStatus) {
+		return false
+	}
+	if v := q["company_type"]; len(v) > 0 && !slices.Contains(v, p.Type) {
+		return false
+	}
+	// Fixture profiles carry no subtype, so a subtype filter matches nothing.
+	if len(q["company_subtype"]) > 0 {
+		return false
+	}
+	if v := q["sic_codes"]; len(v) > 0 && !slices.ContainsFunc(p.SICCodes, func(code string) bool { return slices.Contains(v, code) }) {
+		return false
+	}
+	if v := q.Get("location"); v != "" && !strings.Contains(strings.ToLower(addressText(p.RegisteredOffice)), strings.ToLower(v)) {
+		return false
+	}
+	if !inRange(p.DateOfCreation, q.Get("incorporated_from"), q.Get("incorporated_to")) {
+		return false
+	}
+	if q.Get("dissolved_from") != "" || q.Get("dissolved_to") != "" {
+		if p.DateOfCessation == "" || !inRange(p.DateOfCessation, q.Get("dissolved_from"), q.Get("dissolved_to")) {
+			return false
+		}
+	}
+	return true
+}
+
+// inRange reports whether the ISO date d falls within [from, to]; an empty
+// bound is open.
+func inRange(d, from, to string) bool {
+	return (from == "" || d >= from) && (to == "" || d <= to)
+}
+
+func addressText(a chapi.RegisteredOffice) string {
+	return strings.Join([]string{a.AddressLine1, a.AddressLine2, a.Locality, a.Region, a.PostalCode, a.Country}, " ")
+}
+
+func advancedItem(p chapi.CompanyProfile) chapi.AdvancedSearchItem {
+	return chapi.AdvancedSearchItem{
+		CompanyName:      p.CompanyName,
+		CompanyNumber:    p.CompanyNumber,
+		CompanyStatus:    p.CompanyStatus,
+		CompanyType:      p.Type,
+		Kind:             "search-results#company",
+		DateOfCreation:   p.DateOfCreation,
+		DateOfCessation:  p.DateOfCessation,
+		RegisteredOffice: p.RegisteredOffice,
+		SICCodes:         p.SICCodes,
+		Links:            map[string]string{"company_profile": "/company/" + p.CompanyNumber},
+	}
+}
+
+// alphaKey builds an ordered_alpha_key_with_id: the name reduced to upper-case
+// letters and digits, then the company number.
+func alphaKey(name, number string) string {
+	return alphaName(name) + ":" + number
+}
+
+func alphaName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, name)
+}
+
+// sortedProfiles returns the profiles that satisfy keep in alphabetical key
+// order. The caller must hold s.mu.
+func (s *Server) sortedProfiles(keep func(chapi.CompanyProfile) bool) []chapi.CompanyProfile {
+	var out []chapi.CompanyProfile
+	for _, n := range s.numbers {
+		if p := s.companies[n].Profile; keep(p) {
+			out = append(out, p)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return alphaKey(out[i].CompanyName, out[i].CompanyNumber) < alphaKey(out[j].CompanyName, out[j].CompanyNumber)
+	})
+	return out
+}
+
+// handleAlphabeticalSearch pages through the live register in name order
+// around the query, or above/below an ordered_alpha_key_with_id.
+func (s *Server) handleAlphabeticalSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	size := 20
+	if v, err := strconv.Atoi(q.Get("size")); err == nil && v > 0 {
+		size = min(v, 100)
+	}
+
+	s.mu.Lock()
+	profiles := s.sortedProfiles(func(p chapi.CompanyProfile) bool { return p.CompanyStatus != "dissolved" })
+	s.mu.Unlock()
+
+	items := make([]chapi.AlphabeticalSearchItem, len(profiles))
+	for i, p := range profiles {
+		items[i] = chapi.AlphabeticalSearchItem{
+			CompanyName:           p.CompanyName,
+			CompanyNumber:         p.CompanyNumber,
+			CompanyStatus:         p.CompanyStatus,
+			CompanyType:           p.Type,
+			Kind:                  "search-results#alphabetical-search",
+			OrderedAlphaKeyWithID: alphaKey(p.CompanyName, p.CompanyNumber),
+			Links:                 map[string]string{"company_profile": "/company/" + p.CompanyNumber},
+		}
+	}
+	at := func(key string) int {
+		return sort.Search(len(items), func(i int) bool { return items[i].OrderedAlphaKeyWithID >= key })
+	}
+
+	pos := at(alphaName(q.Get("q")))
+	var from, to int
+	switch {
+	case q.Get("search_below") != "":
+		from = at(q.Get("search_below"))
+		if from < len(items) && items[from].OrderedAlphaKeyWithID == q.Get("search_below") {
+			from++
+		}
+		to = min(from+size, len(items))
+	case q.Get("search_above") != "":
+		to = at(q.Get("search_above"))
+		from = max(to-size, 0)
+	default:
+		from = max(min(pos-size/2, len(items)-size), 0)
+		to = min(from+size, len(items))
+	}
+
+	result := chapi.AlphabeticalSearchResult{
+		Kind:  "search#alphabetical-search",
+		Items: items[from:to],
+	}
+	if len(items) > 0 {
+		result.TopHit = &items[min(pos, len(items)-1)]
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleDissolvedSearch searches dissolved companies. Alphabetical searches
+// list names from the query onwards, or before search_above or after
+// search_below; best-match and previous-name-dissolved match on name, the
+// latter also on previous names. Like the real API, a search with no matches
+// is a 404.
+func (s *Server) handleDissolvedSearch(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	query := strings.ToLower(q.Get("q"))
+	start, _ := strconv.Atoi(q.Get("start_index"))
+	size := 20
+	if v, err := strconv.Atoi(q.Get("size")); err == nil && v > 0 {
+		size = min(v, 100)
+	}
+	searchType := q.Get("search_type")
+	switch searchType {
+	case chapi.DissolvedSearchAlphabetical, chapi.DissolvedSearchBestMatch, chapi.DissolvedSearchPreviousName:
+	default:
+		validationError(w, "search_type must be one of alphabetical, best-match or previous-name-dissolved", "search_type")
+		return
+	}
+
+	s.mu.Lock()
+	profiles := s.sortedProfiles(func(p chapi.CompanyProfile) bool { return p.CompanyStatus == "dissolved" })
+	s.mu.Unlock()
+
+	var matches []chapi.DissolvedSearchItem
+	for _, p := range profiles {
+		item := chapi.DissolvedSearchItem{
+			CompanyName:           p.CompanyName,
+			CompanyNumber:         p.CompanyNumber,
+			CompanyStatus:         p.CompanyStatus,
+			Kind:                  "searchresults#dissolved-company",
+			DateOfCreation:        p.DateOfCreation,
+			DateOfCessation:       p.DateOfCessation,
+			RegisteredOffice:      &p.RegisteredOffice,
+			PreviousCompanyNames:  p.PreviousCompanyNames,
+			OrderedAlphaKeyWithID: alphaKey(p.CompanyName, p.CompanyNumber),
+		}
+		switch {
+		case searchType == chapi.DissolvedSearchAlphabetical:
+			key := item.OrderedAlphaKeyWithID
+			switch {
+			case q.Get("search_below") != "":
+				if key <= q.Get("search_below") {
+					continue
+				}
+			case q.Get("search_above") != "":
+				if key >= q.Get("search_above") {
+					continue
+				}
+			case key < alphaName(query):
+				continue
+			}
+		case strings.Contains(strings.ToLower(p.CompanyName), query):
+		case searchType == chapi.DissolvedSearchPreviousName:
+			i := slices.IndexFunc(p.PreviousCompanyNames, func(n chapi.PreviousCompanyName) bool {
+				return strings.Contains(strings.ToLower(n.Name), query)
+			})
+			if i < 0 {
+				continue
+			}
+			item.MatchedPreviousCompanyName = &p.PreviousCompanyNames[i]
+		default:
+			continue
+		}
+		matches = append(matches, item)
+	}
+
+	if len(matches) == 0 {
+		notFound(w, "no-results-found")
+		return
+	}
+	if searchType == chapi.DissolvedSearchAlphabetical && q.Get("search_above") != "" {
+		start = len(matches) - size
+	}
+	result := chapi.DissolvedSearchResult{
+		Hits:  len(matches),
+		Kind:  "search#dissolved",
+		Items: page(matches, max(start, 0), size),
+	}
+	if len(matches) > 0 {
+		result.TopHit = &matches[0]
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleSearch serves the mixed /search results: companies, then officers,
+// then disqualified officers, with titles that start with the query ranked
+// ahead of those that merely contain it.
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	q := strings.ToLower(r.URL.Query().Get("q"))
+	start, size := pageParams(r, 20)
+
+	var matches []chapi.SearchItem
+	add := func(item chapi.SearchItem) {
+		if strings.Contains(strings.ToLower(item.Title), q) {
+			matches = append(matches, item)
+		}
+	}
+
+	s.mu.Lock()
+	officers := make(map[string]*chapi.SearchItem)
+	appointments := make(map[string]int)
+	var officerIDs []string
+	for _, n := range s.numbers {
+		c := s.companies[n]
+		p := c.Profile
+		desc := p.CompanyNumber + " - Incorporated on " + longDate(p.DateOfCreation)
+		if p.DateOfCessation != "" {
+			desc += " - Dissolved on " + longDate(p.DateOfCessation)
+		}
+		add(chapi.SearchItem{
+			Kind:           "searchresults#company",
+			Title:          p.CompanyName,
+			Description:    desc,
+			AddressSnippet: addressSnippet(p.RegisteredOffice),
+			Address:        &p.RegisteredOffice,
+			CompanyNumber:  p.CompanyNumber,
+			CompanyStatus:  p.CompanyStatus,
+			CompanyType:    p.Type,
+			DateOfCreation: p.DateOfCreation,
+			Links:          map[string]string{"self": "/company/" + p.CompanyNumber},
+		})
+		for _, o := range c.Officers {
+			id := o.OfficerID()
+			if id == "" {
+				continue
+			}
+			appointments[id]++
+			if _, ok := officers[id]; ok {
+				continue
+			}
+			item := &chapi.SearchItem{
+				Kind:           "searchresults#officer",
+				Title:          o.Name,
+				AddressSnippet: addressSnippet(o.Address),
+				Address:        &o.Address,
+				Links:          map[string]string{"self": "/officers/" + id + "/appointments"},
+			}
+			if o.DateOfBirth != nil {
+				item.Description = " - Born " + time.Month(o.DateOfBirth.Month).String() + " " + strconv.Itoa(o.DateOfBirth.Year)
+			}
+			officers[id] = item
+			officerIDs = append(officerIDs, id)
+		}
+	}
+	s.mu.Unlock()
+
+	for _, id := range officerIDs {
+		item := officers[id]
+		item.Description = "Total number of appointments " + strconv.Itoa(appointments[id]) + item.Description
+		add(*item)
+	}
+	for _, o := range s.disqualified {
+		item := chapi.SearchItem{
+			Kind:  "searchresults#disqualified-officer",
+			Title: o.Name(),
+			Links: map[string]string{"self": o.Links["self"]},
+		}
+		if o.DateOfBirth != "" {
+			item.Description = "Born on " + o.DateOfBirth
+		}
+		if len(o.Disqualifications) > 0 {
+			item.AddressSnippet = addressSnippet(o.Disqualifications[0].Address.RegisteredOffice)
+		}
+		add(item)
+	}
+	for _, o := range s.corporates {
+		item := chapi.SearchItem{
+			Kind:  "searchresults#disqualified-officer",
+			Title: o.Name,
+			Links: map[string]string{"self": o.Links["self"]},
+		}
+		if len(o.Disqualifications) > 0 {
+			item.AddressSnippet = addressSnippet(o.Disqualifications[0].Address.RegisteredOffice)
+		}
+		add(item)
+	}
+
+	slices.SortStableFunc(matches, func(a, b chapi.SearchItem) int {
+		ap := strings.HasPrefix(strings.ToLower(a.Title), q)
+		bp := strings.HasPrefix(strings.ToLower(b.Title), q)
+		switch {
+		case ap && !bp:
+			return -1
+		case bp && !ap:
+			return 1
+		}
+		return 0
+	})
+
+	writeJSON(w, http.StatusOK, chapi.SearchAllResult{
+		TotalResults: len(matches),
+		Items:        page(matches, start, size),
+		StartIndex:   start,
+		ItemsPerPage: size,
+	})
+}
+
+// addressSnippet joins the non-empty parts of an address the way search
+// results present it.
+func addressSnippet(a chapi.RegisteredOffice) string {
+	var parts []string
+	for _, p := range []string{a.AddressLine1, a.AddressLine2, a.Locality, a.Region, a.PostalCode} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// longDate formats an ISO date as "27 November 1947".
+func longDate(d string) string {
+	t, err := time.Parse(time.DateOnly, d)
+	if err != nil {
+		return d
+	}
+	return t.Format("2 January 2006")
+}
@@ -0,0 +1,152 @@
+package chfake
+
+import (
+	"encoding/json"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// StreamPath is where the fake serves the Streaming API; point a stream
+// client at the server URL plus StreamPath.
+const StreamPath = "/stream"
+
+// streamHeartbeat is how often an idle stream sends a blank line, as the
+// real Streaming API does to keep connections open.
+const streamHeartbeat = 10 * time.Second
+
+// streamEpoch is the published_at time of timepoint zero.
+var streamEpoch = time.Date(2024, 11, 5, 9, 0, 0, 0, time.UTC)
+
+// streamEvents replays the dataset as one "changed" event per resource,
+// numbered from timepoint 1 in dataset order.
+func (s *Server) streamEvents(resource string) []chapi.StreamEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var events []chapi.StreamEvent
+	add := func(kind, uri string, data any) {
+		b, _ := json.Marshal(data)
+		tp := int64(len(events) + 1)
+		events = append(events, chapi.StreamEvent{
+			ResourceKind: kind,
+			ResourceURI:  uri,
+			ResourceID:   path.Base(uri),
+			Data:         b,
+			Event: chapi.StreamEventInfo{
+				Timepoint:   tp,
+				PublishedAt: streamEpoch.Add(time.Duration(tp) * time.Minute).Format("2006-01-02T15:04:05"),
+				Type:        "changed",
+			},
+		})
+	}
+
+	switch resource {
+	case "disqualified-officers":
+		for _, o := range s.disqualified {
+			add("disqualified-officer-natural", o.Links["self"], o)
+		}
+		for _, o := range s.corporates {
+			add("disqualified-officer-corporate", o.Links["self"], o)
+		}
+		return events
+	}
+	for _, n := range s.numbers {
+		c := s.companies[n]
+		switch resource {
+		case "companies":
+			add("company-profile", "/company/"+n, c.Profile)
+		case "filings":
+			for _, f := range c.Filings {
+				add("filing-history", "/company/"+n+"/filing-history/"+f.TransactionID, f)
+			}
+		case "officers":
+			for _, o := range c.Officers {
+				add("company-officers", o.Links.Self, o)
+			}
+		case "persons-with-significant-control":
+			for _, p := range c.PSCs {
+				add("company-psc-"+pscStreamKind(p.Kind), p.Links["self"], p)
+			}
+		case "persons-with-significant-control-statements":
+			for _, st := range c.PSCStatements {
+				add("company-psc-statement", st.Links["self"], st)
+			}
+		case "charges":
+			for _, ch := range c.Charges {
+				add("company-charges", ch.Links["self"], ch)
+			}
+		case "insolvency-cases":
+			if c.Insolvency != nil {
+				add("company-insolvency", "/company/"+n+"/insolvency", c.Insolvency)
+			}
+		}
+	}
+	return events
+}
+
+// pscStreamKind maps a PSC kind to the suffix of its stream resource kind.
+func pscStreamKind(kind string) string {
+	switch kind {
+	case chapi.PSCKindCorporateEntity:
+		return "corporate"
+	case chapi.PSCKindLegalPerson:
+		return "legal"
+	case chapi.PSCKindSuperSecure:
+		return "super-secure"
+	}
+	return "individual"
+}
+
+// handleStream writes every event from the requested timepoint (or from the
+// start), then heartbeats until the client disconnects. A timepoint beyond
+// the end of the stream is rejected with 416, as the real API rejects
+// timepoints it no longer holds.
+func (s *Server) handleStream(w http.ResponseWriter, r *http.Request) {
+	resource := r.PathValue("resource")
+	if !slices.Contains(chapi.StreamResources, resource) {
+		http.NotFound(w, r)
+		return
+	}
+	events := s.streamEvents(resource)
+	from := int64(1)
+	if v := r.URL.Query().Get("timepoint"); v != "" {
+		tp, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || tp < 1 || tp > int64(len(events))+1 {
+			writeJSON(w, http.StatusRequestedRangeNotSatisfiable, map[string]string{
+				"error": "timepoint out of range",
+				"type":  "ch:service",
+			})
+			return
+		}
+		from = tp
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	flusher, _ := w.(http.Flusher)
+	enc := json.NewEncoder(w)
+	for _, e := range events[from-1:] {
+		enc.Encode(e)
+	}
+	if flusher != nil {
+		flusher.Flush()
+	}
+
+	tick := time.NewTicker(streamHeartbeat)
+	defer tick.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-tick.C:
+			w.Write([]byte("\n"))
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
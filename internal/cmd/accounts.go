@@ -0,0 +1,447 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/ixbrl"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// AccountsCmd extracts financial figures from filed accounts.
+type AccountsCmd struct {
+	Facts   AccountsFactsCmd   `cmd:"" help:"Extract tagged financial facts from an iXBRL accounts filing"`
+	History AccountsHistoryCmd `cmd:"" help:"Year-by-year key figures with growth, from every iXBRL accounts filing"`
+}
+
+// errNoIXBRL marks accounts filed only as PDF (paper or image filings).
+var errNoIXBRL = errors.New("accounts were not filed as iXBRL")
+
+// AccountsFactsCmd extracts the facts tagged in one set of accounts.
+type AccountsFactsCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+	TransactionID string `arg:"" optional:"" help:"Accounts filing transaction ID (default: the latest accounts filed as iXBRL)"`
+	File          string `help:"Parse a local iXBRL file instead of fetching one" type:"existingfile"`
+	All           bool   `help:"List every tagged fact, not just the headline figures"`
+}
+
+// accountsFacts is the JSON output of accounts facts.
+type accountsFacts struct {
+	CompanyNumber string         `json:"company_number,omitempty"`
+	TransactionID string         `json:"transaction_id,omitempty"`
+	FilingDate    string         `json:"filing_date,omitempty"`
+	EntityName    string         `json:"entity_name,omitempty"`
+	ReportingDate string         `json:"reporting_date"`
+	Figures       []ixbrl.Figure `json:"figures"`
+	Facts         []ixbrl.Fact   `json:"facts,omitempty"`
+}
+
+func (c *AccountsFactsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	var (
+		doc    *ixbrl.Document
+		result accountsFacts
+	)
+	if c.File != "" {
+		f, err := os.Open(c.File)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if doc, err = ixbrl.Parse(f); err != nil {
+			return fmt.Errorf("parse %s: %w", c.File, err)
+		}
+	} else {
+		cn, err := resolveCompanyNumber(c.CompanyNumber)
+		if err != nil {
+			return err
+		}
+		client, err := newClient(flags)
+		if err != nil {
+			return err
+		}
+		docs, err := newDocumentClient(flags)
+		if err != nil {
+			return err
+		}
+		var filing *chapi.FilingHistoryItem
+		if c.TransactionID != "" {
+			if filing, err = client.GetFilingHistoryItem(ctx, cn, c.TransactionID); err != nil {
+				return fmt.Errorf("get filing: %w", err)
+			}
+			if doc, err = loadAccounts(ctx, docs, *filing); err != nil {
+				return fmt.Errorf("accounts %s: %w", c.TransactionID, err)
+			}
+		} else if filing, doc, err = latestAccounts(ctx, client, docs, cn); err != nil {
+			return err
+		}
+		result.CompanyNumber = cn
+		result.TransactionID = filing.TransactionID
+		result.FilingDate = filing.Date
+	}
+
+	if u := ui.FromContext(ctx); u != nil {
+		for _, w := range doc.Warnings {
+			u.Warn("skipped fact: " + w)
+		}
+	}
+
+	result.EntityName = doc.EntityName()
+	result.ReportingDate = doc.ReportingDate()
+	result.Figures = doc.Figures()
+	if c.All {
+		result.Facts = doc.Facts
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	title := result.EntityName
+	if title == "" {
+		title = result.CompanyNumber
+	}
+	fmt.Fprintf(os.Stdout, "%s: accounts to %s", title, result.ReportingDate)
+	if result.TransactionID != "" {
+		fmt.Fprintf(os.Stdout, " (filed %s, transaction %s)", result.FilingDate, result.TransactionID)
+	}
+	fmt.Fprintln(os.Stdout)
+
+	if c.All {
+		fmt.Fprintln(os.Stdout)
+		for _, f := range result.Facts {
+			value := f.Text
+			if f.Value != nil {
+				value = formatFigure(*f.Value, f.Unit)
+			} else if r := []rune(value); len(r) > 40 {
+				value = string(r[:37]) + "..."
+			}
+			fmt.Fprintf(os.Stdout, "  %-60s  %-24s  %s%s\n", f.Name, f.Period, value, formatDimensions(f.Dimensions))
+		}
+		return nil
+	}
+	if len(result.Figures) == 0 {
+		fmt.Fprintln(os.Stdout, "\nNo headline figures tagged (use --all to list every fact).")
+		return nil
+	}
+	printFigureTable(result.Figures)
+	return nil
+}
+
+// AccountsHistoryCmd builds a multi-year series from a company's accounts.
+type AccountsHistoryCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+	Years         int    `help:"Number of years to show" default:"5"`
+}
+
+// accountsHistory is the JSON output of accounts history.
+type accountsHistory struct {
+	CompanyNumber string `json:"company_number"`
+	EntityName    string `json:"entity_name,omitempty"`
+	ixbrl.Series
+	Skipped []skippedAccounts `json:"skipped,omitempty"`
+}
+
+// skippedAccounts is an accounts filing left out of a history, and why.
+type skippedAccounts struct {
+	TransactionID string `json:"transaction_id"`
+	Date          string `json:"date"`
+	Reason        string `json:"reason"`
+}
+
+func (c *AccountsHistoryCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	if c.Years < 1 {
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("--years must be at least 1")}
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	docs, err := newDocumentClient(flags)
+	if err != nil {
+		return err
+	}
+	filings, err := accountsFilings(ctx, client, cn)
+	if err != nil {
+		return err
+	}
+
+	result := accountsHistory{CompanyNumber: cn}
+	var parsed []ixbrl.Filing
+	dates := map[string]bool{}
+	for _, f := range filings {
+		if len(dates) >= c.Years {
+			break
+		}
+		doc, err := loadAccounts(ctx, docs, f)
+		if err != nil {
+			if isFetchFailure(err) {
+				return fmt.Errorf("accounts %s: %w", f.TransactionID, err)
+			}
+			reason := err.Error()
+			switch {
+			case errors.Is(err, errNoIXBRL):
+				reason = "not filed as iXBRL"
+			case errors.Is(err, ixbrl.ErrNoFacts):
+				reason = "no tagged facts"
+			}
+			result.Skipped = append(result.Skipped, skippedAccounts{f.TransactionID, f.Date, reason})
+			continue
+		}
+		if result.EntityName == "" {
+			result.EntityName = doc.EntityName()
+		}
+		parsed = append(parsed, ixbrl.Filing{TransactionID: f.TransactionID, Document: doc})
+		for _, fig := range doc.Figures() {
+			dates[fig.Period.Date()] = true
+		}
+	}
+	if len(parsed) == 0 {
+		return fmt.Errorf("no accounts filed as iXBRL for company %s", cn)
+	}
+	result.Series = ixbrl.BuildSeries(parsed, c.Years)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	title := result.EntityName
+	if title == "" {
+		title = cn
+	}
+	years := "years"
+	if len(result.Years) == 1 {
+		years = "year"
+	}
+	fmt.Fprintf(os.Stdout, "%s: %d %s of accounts\n\n  %-28s", title, len(result.Years), years, "")
+	for _, y := range result.Years {
+		fmt.Fprintf(os.Stdout, "  %12s", y.Date)
+	}
+	fmt.Fprintln(os.Stdout)
+	for _, m := range ixbrl.Measures {
+		if !slices.ContainsFunc(result.Years, func(y ixbrl.Year) bool { _, ok := y.Values[m.Key]; return ok }) {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "  %-28s", m.Label)
+		for _, y := range result.Years {
+			v, ok := y.Values[m.Key]
+			cell := "-"
+			if ok {
+				cell = formatCompact(v, m.Key, y.Units[m.Key])
+			}
+			fmt.Fprintf(os.Stdout, "  %12s", cell)
+		}
+		fmt.Fprintln(os.Stdout)
+		if len(result.Years) < 2 {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "  %-28s", "  growth")
+		for _, y := range result.Years {
+			cell := ""
+			if g, ok := y.Growth[m.Key]; ok {
+				cell = fmt.Sprintf("%+.1f%%", g)
+			}
+			fmt.Fprintf(os.Stdout, "  %12s", cell)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+	for _, r := range result.Restatements {
+		fmt.Fprintf(os.Stdout, "\nNote: %s for %s restated from %s to %s in filing %s",
+			r.Key, r.Date, formatCompact(r.Reported, r.Key, r.Unit), formatCompact(r.Restated, r.Key, r.Unit), r.TransactionID)
+	}
+	if len(result.Restatements) > 0 {
+		fmt.Fprintln(os.Stdout)
+	}
+	if len(result.Skipped) > 0 {
+		fmt.Fprintf(os.Stdout, "\nSkipped %d accounts filing(s):\n", len(result.Skipped))
+		for _, sk := range result.Skipped {
+			fmt.Fprintf(os.Stdout, "  %s  %s  %s\n", sk.Date, sk.TransactionID, sk.Reason)
+		}
+	}
+	return nil
+}
+
+// isFetchFailure reports whether err means the API could not be used at
+// all (network, authentication, rate limiting, server errors or
+// cancellation), as opposed to a problem with one filing's document.
+func isFetchFailure(err error) bool {
+	var urlErr *url.Error
+	return errors.As(err, &urlErr) ||
+		errors.Is(err, chapi.ErrUnauthorized) ||
+		errors.Is(err, chapi.ErrRateLimited) ||
+		errors.Is(err, chapi.ErrServer) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// formatCompact renders a headline figure briefly in its unit (e.g.
+// £68.2bn, €251.9k, 1.2m CHF); headcounts are shown in full.
+func formatCompact(v float64, key, unit string) string {
+	if key == "employees" {
+		return formatFigure(v, "pure")
+	}
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	prefix, suffix := unitAffixes(unit)
+	switch {
+	case v >= 1e9:
+		return fmt.Sprintf("%s%s%.1fbn%s", sign, prefix, v/1e9, suffix)
+	case v >= 1e6:
+		return fmt.Sprintf("%s%s%.1fm%s", sign, prefix, v/1e6, suffix)
+	case v >= 1e4:
+		return fmt.Sprintf("%s%s%.1fk%s", sign, prefix, v/1e3, suffix)
+	}
+	return sign + formatFigure(v, unit)
+}
+
+// printFigureTable prints figures as one row per measure and one column per
+// period date, newest first.
+func printFigureTable(figures []ixbrl.Figure) {
+	var dates, keys []string
+	values := map[string]map[string]ixbrl.Figure{}
+	labels := map[string]string{}
+	for _, f := range figures {
+		d := f.Period.Date()
+		if !slices.Contains(dates, d) {
+			dates = append(dates, d)
+		}
+		if values[f.Key] == nil {
+			values[f.Key] = map[string]ixbrl.Figure{}
+			keys = append(keys, f.Key)
+			labels[f.Key] = f.Label
+		}
+		values[f.Key][d] = f
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(dates)))
+
+	fmt.Fprintf(os.Stdout, "\n  %-28s", "")
+	for _, d := range dates {
+		fmt.Fprintf(os.Stdout, "  %18s", d)
+	}
+	fmt.Fprintln(os.Stdout)
+	for _, k := range keys {
+		fmt.Fprintf(os.Stdout, "  %-28s", labels[k])
+		for _, d := range dates {
+			v := "-"
+			if f, ok := values[k][d]; ok {
+				v = formatFigure(f.Value, f.Unit)
+			}
+			fmt.Fprintf(os.Stdout, "  %18s", v)
+		}
+		fmt.Fprintln(os.Stdout)
+	}
+}
+
+// latestAccounts returns the most recent accounts filing for companyNumber
+// that carries iXBRL, parsed. Older PDF-only filings are skipped.
+func latestAccounts(ctx context.Context, client *chapi.Client, docs *chapi.DocumentClient, companyNumber string) (*chapi.FilingHistoryItem, *ixbrl.Document, error) {
+	filings, err := accountsFilings(ctx, client, companyNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range filings {
+		doc, err := loadAccounts(ctx, docs, filings[i])
+		if errors.Is(err, errNoIXBRL) || errors.Is(err, ixbrl.ErrNoFacts) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("accounts %s: %w", filings[i].TransactionID, err)
+		}
+		return &filings[i], doc, nil
+	}
+	return nil, nil, fmt.Errorf("no accounts filed as iXBRL for company %s", companyNumber)
+}
+
+// accountsFilings lists a company's accounts filings, newest first.
+func accountsFilings(ctx context.Context, client *chapi.Client, companyNumber string) ([]chapi.FilingHistoryItem, error) {
+	list, err := client.ListAllFilingHistory(ctx, companyNumber, "accounts", 0)
+	if err != nil {
+		return nil, fmt.Errorf("list filings: %w", err)
+	}
+	filings := list.Items
+	sort.SliceStable(filings, func(i, j int) bool { return filings[i].Date > filings[j].Date })
+	return filings, nil
+}
+
+// loadAccounts downloads and parses the iXBRL document behind an accounts
+// filing. It returns errNoIXBRL if the document is only available as PDF.
+func loadAccounts(ctx context.Context, docs *chapi.DocumentClient, f chapi.FilingHistoryItem) (*ixbrl.Document, error) {
+	id := chapi.DocumentIDFromLink(f.Links["document_metadata"])
+	if id == "" {
+		return nil, errNoIXBRL
+	}
+	meta, err := docs.GetDocumentMetadata(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get document metadata: %w", err)
+	}
+	if !meta.HasFormat(chapi.ContentTypeXHTML) {
+		return nil, errNoIXBRL
+	}
+	var buf bytes.Buffer
+	if _, err := docs.DownloadDocument(ctx, id, chapi.ContentTypeXHTML, &buf); err != nil {
+		return nil, fmt.Errorf("download document: %w", err)
+	}
+	return ixbrl.Parse(&buf)
+}
+
+// formatFigure renders a value with thousands separators, prefixed with the
+// currency symbol for monetary units.
+func formatFigure(v float64, unit string) string {
+	sign := ""
+	if v < 0 {
+		sign, v = "-", -v
+	}
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	whole, frac, _ := strings.Cut(s, ".")
+	for i := len(whole) - 3; i > 0; i -= 3 {
+		whole = whole[:i] + "," + whole[i:]
+	}
+	if frac != "" {
+		whole += "." + frac
+	}
+	prefix, suffix := unitAffixes(unit)
+	return sign + prefix + whole + suffix
+}
+
+// unitAffixes returns the currency symbol to put before a value in unit, or
+// for currencies without one here, the unit to put after it.
+func unitAffixes(unit string) (prefix, suffix string) {
+	switch unit {
+	case "GBP":
+		return "£", ""
+	case "EUR":
+		return "€", ""
+	case "USD":
+		return "$", ""
+	case "pure", "":
+		return "", ""
+	}
+	return "", " " + unit
+}
+
+func formatDimensions(dims map[string]string) string {
+	if len(dims) == 0 {
+		return ""
+	}
+	parts := make([]string, 0, len(dims))
+	for d, m := range dims {
+		parts = append(parts, d+"="+m)
+	}
+	sort.Strings(parts)
+	return " [" + strings.Join(parts, ", ") + "]"
+}
@@ -0,0 +1,108 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chfake"
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_AccountsFacts(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"accounts", "facts", "00445790", "--json"},
+		{"accounts", "facts", "00445790", "MzM3NjU0MzIxMGFkaXF6a2N4", "--all"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+}
+
+func TestExecute_AccountsFactsNoIXBRL(t *testing.T) {
+	useFake(t)
+
+	if err := cmd.Execute([]string{"accounts", "facts", "13876501"}); err == nil {
+		t.Fatal("Execute(accounts facts) succeeded for a company without iXBRL accounts")
+	}
+	err := cmd.Execute([]string{"accounts", "facts", "00445790", "MzQxMjc2NTQzMWFkaXF6a2N4"})
+	if err == nil {
+		t.Fatal("Execute(accounts facts) succeeded for a confirmation statement")
+	}
+}
+
+func TestExecute_AccountsHistory(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"accounts", "history", "00445790"},
+		{"accounts", "history", "00445790", "--years", "2", "--json"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+
+	err := cmd.Execute([]string{"accounts", "history", "00445790", "--years", "0"})
+	if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+	}
+}
+
+// useFakeFailingDocument serves the fake dataset but answers requests for
+// one document's content with status.
+func useFakeFailingDocument(t *testing.T, documentID string, status int) {
+	t.Helper()
+	useFake(t)
+	fake := chfake.New(chfake.DefaultDataset())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/document/"+documentID+"/content" {
+			w.WriteHeader(status)
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("CH_BASE_URL", srv.URL)
+	t.Setenv("CH_DOCUMENT_URL", srv.URL)
+}
+
+func TestExecute_AccountsHistorySkipsBadFiling(t *testing.T) {
+	useFakeFailingDocument(t, "2271a0ae419b7f2c", http.StatusNotFound)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = cmd.Execute([]string{"accounts", "history", "00445790", "--json"})
+	})
+	if err != nil {
+		t.Fatalf("accounts history error: %v", err)
+	}
+	var result struct {
+		Years   []json.RawMessage `json:"years"`
+		Skipped []struct {
+			TransactionID string `json:"transaction_id"`
+			Reason        string `json:"reason"`
+		} `json:"skipped"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, out)
+	}
+	if len(result.Skipped) != 1 || result.Skipped[0].TransactionID != "MzM3NjU0MzIxMGFkaXF6a2N4" || result.Skipped[0].Reason == "" {
+		t.Errorf("skipped = %+v", result.Skipped)
+	}
+	if len(result.Years) < 2 {
+		t.Errorf("years = %d, want the other filings kept", len(result.Years))
+	}
+}
+
+func TestExecute_AccountsHistoryAuthFailure(t *testing.T) {
+	useFakeFailingDocument(t, "2271a0ae419b7f2c", http.StatusUnauthorized)
+
+	if err := cmd.Execute([]string{"accounts", "history", "00445790"}); err == nil {
+		t.Fatal("accounts history succeeded despite an authentication failure")
+	}
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// ManifestFile is the name of the manifest written into an archive directory.
+const ManifestFile = "manifest.json"
+
+// FilingArchiveCmd downloads every matching filing document for a company.
+type FilingArchiveCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+	Dir           string `help:"Directory to write documents and manifest.json into" default:"." type:"path"`
+	Category      string `help:"Filter by category (e.g. accounts, confirmation-statement)"`
+	Since         string `help:"Only filings made on or after this date (YYYY-MM-DD)"`
+	Format        string `help:"Document format: pdf|xhtml" enum:"pdf,xhtml" default:"pdf"`
+	Concurrency   int    `help:"Documents to download at once" default:"4"`
+}
+
+// Manifest records the contents of a filing archive.
+type Manifest struct {
+	CompanyNumber string          `json:"company_number"`
+	GeneratedAt   time.Time       `json:"generated_at"`
+	Category      string          `json:"category,omitempty"`
+	Since         string          `json:"since,omitempty"`
+	ContentType   string          `json:"content_type"`
+	Files         []ManifestEntry `json:"files"`
+}
+
+// ManifestEntry is one filing in a Manifest.
+type ManifestEntry struct {
+	TransactionID string `json:"transaction_id"`
+	Date          string `json:"date"`
+	Category      string `json:"category"`
+	Type          string `json:"type"`
+	Description   string `json:"description"`
+	DocumentID    string `json:"document_id,omitempty"`
+	File          string `json:"file,omitempty"`
+	Bytes         int64  `json:"bytes,omitempty"`
+	SHA256        string `json:"sha256,omitempty"`
+	Status        string `json:"status"`
+	Error         string `json:"error,omitempty"`
+}
+
+// Manifest entry statuses.
+const (
+	archiveDownloaded = "downloaded"
+	archiveSkipped    = "skipped"
+	archiveNoDocument = "no-document"
+	archiveFailed     = "failed"
+)
+
+func (c *FilingArchiveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	if c.Since != "" {
+		if _, err := time.Parse(time.DateOnly, c.Since); err != nil {
+			return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("--since must be YYYY-MM-DD: %w", err)}
+		}
+	}
+	if c.Concurrency < 1 {
+		c.Concurrency = 1
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	docs, err := newDocumentClient(flags)
+	if err != nil {
+		return err
+	}
+	format := documentFormats[c.Format]
+
+	history, err := client.ListAllFilingHistory(ctx, cn, c.Category, 0)
+	if err != nil {
+		return fmt.Errorf("list filings: %w", err)
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return fmt.Errorf("create archive dir: %w", err)
+	}
+
+	var filings []chapi.FilingHistoryItem
+	for _, f := range history.Items {
+		if c.Since == "" || f.Date >= c.Since {
+			filings = append(filings, f)
+		}
+	}
+
+	u := ui.FromContext(ctx)
+	entries := make([]ManifestEntry, len(filings))
+	results := make(chan int)
+	sem := make(chan struct{}, c.Concurrency)
+	var wg sync.WaitGroup
+	for i, f := range filings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			entries[i] = archiveFiling(ctx, docs, c.Dir, f, format.contentType, format.ext)
+			results <- i
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	failed := 0
+	for i := range results {
+		e := entries[i]
+		switch e.Status {
+		case archiveFailed:
+			failed++
+			if u != nil {
+				u.Warn(fmt.Sprintf("%s: %s", e.TransactionID, e.Error))
+			}
+		case archiveDownloaded:
+			if u != nil && !outfmt.IsJSON(ctx) {
+				u.Info("Downloaded " + e.File)
+			}
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Date != entries[j].Date {
+			return entries[i].Date < entries[j].Date
+		}
+		return entries[i].TransactionID < entries[j].TransactionID
+	})
+	manifest := Manifest{
+		CompanyNumber: cn,
+		GeneratedAt:   time.Now().UTC().Truncate(time.Second),
+		Category:      c.Category,
+		Since:         c.Since,
+		ContentType:   format.contentType,
+		Files:         entries,
+	}
+	if err := writeManifest(filepath.Join(c.Dir, ManifestFile), manifest); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(os.Stdout, manifest); err != nil {
+			return err
+		}
+	} else {
+		counts := map[string]int{}
+		for _, e := range entries {
+			counts[e.Status]++
+		}
+		fmt.Fprintf(os.Stdout, "Archived %d filings for %s in %s: %d downloaded, %d already present, %d without documents, %d failed\n",
+			len(entries), cn, c.Dir, counts[archiveDownloaded], counts[archiveSkipped], counts[archiveNoDocument], counts[archiveFailed])
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d documents failed to download", failed, len(entries))
+	}
+	return nil
+}
+
+// archiveFiling downloads one filing's document into dir unless it is
+// already there, and returns its manifest entry.
+func archiveFiling(ctx context.Context, docs *chapi.DocumentClient, dir string, f chapi.FilingHistoryItem, contentType, ext string) ManifestEntry {
+	e := ManifestEntry{
+		TransactionID: f.TransactionID,
+		Date:          f.Date,
+		Category:      f.Category,
+		Type:          f.Type,
+		Description:   f.Description,
+		DocumentID:    chapi.DocumentIDFromLink(f.Links["document_metadata"]),
+	}
+	if e.DocumentID == "" {
+		e.Status = archiveNoDocument
+		return e
+	}
+	e.File = archiveFileName(f, ext)
+	path := filepath.Join(dir, e.File)
+
+	if sum, n, err := fileSHA256(path); err == nil {
+		e.Status, e.SHA256, e.Bytes = archiveSkipped, sum, n
+		return e
+	}
+
+	h := sha256.New()
+	n, err := writeFileAtomic(path, func(w io.Writer) (int64, error) {
+		return docs.DownloadDocument(ctx, e.DocumentID, contentType, io.MultiWriter(w, h))
+	})
+	if err != nil {
+		e.Status, e.Error = archiveFailed, err.Error()
+		return e
+	}
+	e.Status, e.SHA256, e.Bytes = archiveDownloaded, hex.EncodeToString(h.Sum(nil)), n
+	return e
+}
+
+// archiveFileName names a filing's document by date, form type and
+// transaction ID, so names sort chronologically and never collide.
+func archiveFileName(f chapi.FilingHistoryItem, ext string) string {
+	typ := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-':
+			return r
+		}
+		return '-'
+	}, f.Type)
+	if typ == "" {
+		typ = "document"
+	}
+	return fmt.Sprintf("%s_%s_%s%s", f.Date, typ, f.TransactionID, ext)
+}
+
+func fileSHA256(path string) (string, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+func writeManifest(path string, m Manifest) error {
+	b, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode manifest: %w", err)
+	}
+	b = append(b, '\n')
+	if _, err := writeFileAtomic(path, func(w io.Writer) (int64, error) {
+		n, err := w.Write(b)
+		return int64(n), err
+	}); err != nil {
+		return fmt.Errorf("write manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_FilingArchive(t *testing.T) {
+	useFake(t)
+
+	dir := t.TempDir()
+	args := []string{"filing", "archive", "00445790", "--dir", dir, "--category", "accounts", "--since", "2020-01-01", "--no-cache"}
+	if err := cmd.Execute(args); err != nil {
+		t.Fatalf("Execute(filing archive) error: %v", err)
+	}
+
+	manifest := readManifest(t, dir)
+	if len(manifest.Files) == 0 {
+		t.Fatal("manifest lists no files")
+	}
+	for _, f := range manifest.Files {
+		if f.Category != "accounts" || f.Date < "2020-01-01" {
+			t.Errorf("unexpected filing in manifest: %+v", f)
+		}
+		if f.Status != "downloaded" || f.SHA256 == "" {
+			t.Errorf("file %s: status %q sha256 %q", f.File, f.Status, f.SHA256)
+		}
+		if _, err := os.Stat(filepath.Join(dir, f.File)); err != nil {
+			t.Errorf("missing archived file: %v", err)
+		}
+	}
+
+	if err := cmd.Execute(args); err != nil {
+		t.Fatalf("second Execute(filing archive) error: %v", err)
+	}
+	again := readManifest(t, dir)
+	for i, f := range again.Files {
+		if f.Status != "skipped" {
+			t.Errorf("file %s: status %q on re-run, want skipped", f.File, f.Status)
+		}
+		if f.SHA256 != manifest.Files[i].SHA256 {
+			t.Errorf("file %s: checksum changed on re-run", f.File)
+		}
+	}
+}
+
+func TestExecute_FilingArchiveBadSince(t *testing.T) {
+	t.Setenv("CH_CONFIG_DIR", t.TempDir())
+	t.Setenv("CH_API_KEY", "test-key")
+
+	err := cmd.Execute([]string{"filing", "archive", "00445790", "--dir", t.TempDir(), "--since", "2015"})
+	if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+		t.Fatalf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+	}
+}
+
+func readManifest(t *testing.T, dir string) cmd.Manifest {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join(dir, cmd.ManifestFile))
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var m cmd.Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("decode manifest: %v", err)
+	}
+	return m
+}
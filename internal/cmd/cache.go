@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// CacheCmd manages the on-disk response cache.
+type CacheCmd struct {
+	Stats CacheStatsCmd `cmd:"" help:"Show cache size and entry counts"`
+	Prune CachePruneCmd `cmd:"" help:"Remove expired cache entries"`
+	Purge CachePurgeCmd `cmd:"" help:"Remove every cache entry"`
+}
+
+// CacheStatsCmd shows cache statistics.
+type CacheStatsCmd struct{}
+
+func (c *CacheStatsCmd) Run(ctx context.Context) error {
+	store, err := openCache()
+	if err != nil {
+		return err
+	}
+	st, err := store.Stats(time.Now())
+	if err != nil {
+		return fmt.Errorf("cache stats: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, st)
+	}
+
+	fmt.Fprintf(os.Stdout, "%-15s %s\n", "Directory:", st.Dir)
+	fmt.Fprintf(os.Stdout, "%-15s %d (%d expired)\n", "Entries:", st.Entries, st.Expired)
+	fmt.Fprintf(os.Stdout, "%-15s %d bytes\n", "Size:", st.Bytes)
+	if !st.Oldest.IsZero() {
+		fmt.Fprintf(os.Stdout, "%-15s %s\n", "Oldest:", st.Oldest.Local().Format("2006-01-02 15:04"))
+		fmt.Fprintf(os.Stdout, "%-15s %s\n", "Newest:", st.Newest.Local().Format("2006-01-02 15:04"))
+	}
+	return nil
+}
+
+// CachePruneCmd removes expired cache entries.
+type CachePruneCmd struct{}
+
+func (c *CachePruneCmd) Run(ctx context.Context) error {
+	store, err := openCache()
+	if err != nil {
+		return err
+	}
+	removed, err := store.Prune(time.Now())
+	if err != nil {
+		return fmt.Errorf("prune cache: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"removed": removed})
+	}
+	if u := ui.FromContext(ctx); u != nil {
+		u.Success(fmt.Sprintf("Removed %d expired entries.", removed))
+	}
+	return nil
+}
+
+// CachePurgeCmd removes every cache entry.
+type CachePurgeCmd struct{}
+
+func (c *CachePurgeCmd) Run(ctx context.Context) error {
+	store, err := openCache()
+	if err != nil {
+		return err
+	}
+	removed, err := store.Purge()
+	if err != nil {
+		return fmt.Errorf("purge cache: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, map[string]any{"removed": removed})
+	}
+	if u := ui.FromContext(ctx); u != nil {
+		u.Success(fmt.Sprintf("Removed %d entries.", removed))
+	}
+	return nil
+}
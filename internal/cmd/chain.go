@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// PSCChainCmd traces a company's ownership through corporate PSCs to its
+// ultimate beneficial owners.
+type PSCChainCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+	Depth         int    `help:"Maximum layers of companies to follow" default:"5"`
+	Format        string `help:"Output format: text|json|dot" enum:"text,json,dot" default:"text"`
+}
+
+func (c *PSCChainCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	if c.Depth < 1 {
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("--depth must be at least 1")}
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	root, err := client.ResolveOwnershipChain(ctx, cn, c.Depth)
+	if err != nil {
+		return fmt.Errorf("resolve ownership chain: %w", err)
+	}
+	if u := ui.FromContext(ctx); u != nil {
+		walkOwnership(root, nil, func(n *chapi.OwnershipNode, _ []*chapi.OwnershipNode) {
+			switch n.Stop {
+			case chapi.ChainStopError:
+				u.Warn(fmt.Sprintf("could not fetch PSCs of %s (%s): %s", n.Name, n.CompanyNumber, n.Error))
+			case chapi.ChainStopNotFound:
+				u.Warn(fmt.Sprintf("%s gives company number %s, which is not on the register", n.Name, n.CompanyNumber))
+			case chapi.ChainStopDepth:
+				u.Warn(fmt.Sprintf("stopped at %s (%s): depth limit of %d reached", n.Name, n.CompanyNumber, c.Depth))
+			}
+		})
+	}
+
+	switch {
+	case outfmt.IsJSON(ctx) || c.Format == "json":
+		return outfmt.WriteJSON(os.Stdout, root)
+	case c.Format == "dot":
+		writeOwnershipDOT(os.Stdout, root)
+	default:
+		writeOwnershipText(os.Stdout, root)
+	}
+	return nil
+}
+
+// walkOwnership calls fn for every node below and including n, with the
+// nodes above it from the root down.
+func walkOwnership(n *chapi.OwnershipNode, above []*chapi.OwnershipNode, fn func(n *chapi.OwnershipNode, above []*chapi.OwnershipNode)) {
+	fn(n, above)
+	above = append(above, n)
+	for _, o := range n.Owners {
+		walkOwnership(o, above, fn)
+	}
+}
+
+// ownershipLabel describes a node on one line: name, number, holding and
+// why the chain stops there.
+func ownershipLabel(n *chapi.OwnershipNode) string {
+	parts := []string{n.Name}
+	if n.CompanyNumber != "" {
+		parts[0] += " (" + n.CompanyNumber + ")"
+	}
+	if n.Kind != chapi.OwnerKindCompany {
+		parts = append(parts, n.Kind)
+	}
+	if n.Ownership != nil {
+		parts = append(parts, "shares "+n.Ownership.String())
+	} else if n.Kind != chapi.OwnerKindCompany {
+		parts = append(parts, "control without shares")
+	}
+	if n.EffectiveOwnership != nil {
+		parts = append(parts, "effective "+n.EffectiveOwnership.String())
+	}
+	switch n.Stop {
+	case "", chapi.ChainStopIndividual:
+	case chapi.ChainStopForeign:
+		parts = append(parts, fmt.Sprintf("[foreign: %s]", cmp.Or(n.CountryRegistered, "unknown")))
+	case chapi.ChainStopError:
+		parts = append(parts, "[error: "+n.Error+"]")
+	default:
+		parts = append(parts, "["+n.Stop+"]")
+	}
+	return strings.Join(parts, "  ")
+}
+
+func writeOwnershipText(w io.Writer, root *chapi.OwnershipNode) {
+	fmt.Fprintln(w, ownershipLabel(root))
+	var tree func(n *chapi.OwnershipNode, indent string)
+	tree = func(n *chapi.OwnershipNode, indent string) {
+		for i, o := range n.Owners {
+			branch, next := "├── ", "│   "
+			if i == len(n.Owners)-1 {
+				branch, next = "└── ", "    "
+			}
+			fmt.Fprintf(w, "%s%s%s\n", indent, branch, ownershipLabel(o))
+			tree(o, indent+next)
+		}
+	}
+	tree(root, "")
+
+	var ubos []string
+	walkOwnership(root, nil, func(n *chapi.OwnershipNode, above []*chapi.OwnershipNode) {
+		if n.Stop != chapi.ChainStopIndividual {
+			return
+		}
+		holding := "control without shares"
+		if n.EffectiveOwnership != nil {
+			holding = "effective " + n.EffectiveOwnership.String()
+		}
+		var via []string
+		for _, a := range above[1:] {
+			via = append(via, a.Name)
+		}
+		line := fmt.Sprintf("  %-40s  %s", n.Name, holding)
+		if len(via) > 0 {
+			line += "  via " + strings.Join(via, " > ")
+		}
+		ubos = append(ubos, line)
+	})
+	fmt.Fprintf(w, "\nUltimate Beneficial Owners (%d):\n\n", len(ubos))
+	for _, l := range ubos {
+		fmt.Fprintln(w, l)
+	}
+}
+
+// writeOwnershipDOT renders the tree as a Graphviz digraph with edges from
+// owner to owned. Companies are keyed by number, so a company reached by
+// several paths, or through a cycle, is drawn once. Other owners are keyed by
+// their place under the company they own, so namesakes stay apart.
+func writeOwnershipDOT(w io.Writer, root *chapi.OwnershipNode) {
+	ids := map[string]string{}
+	edges := map[string]bool{}
+	var nodeID func(n *chapi.OwnershipNode, above []*chapi.OwnershipNode) (string, bool)
+	nodeID = func(n *chapi.OwnershipNode, above []*chapi.OwnershipNode) (string, bool) {
+		key := "company:" + n.CompanyNumber
+		if n.CompanyNumber == "" && len(above) > 0 {
+			parent := above[len(above)-1]
+			parentID, _ := nodeID(parent, above[:len(above)-1])
+			key = fmt.Sprintf("%s/%d", parentID, slices.Index(parent.Owners, n))
+		}
+		if id, ok := ids[key]; ok {
+			return id, false
+		}
+		ids[key] = fmt.Sprintf("n%d", len(ids))
+		return ids[key], true
+	}
+
+	fmt.Fprintln(w, "digraph ownership {")
+	fmt.Fprintln(w, "  node [shape=box];")
+	walkOwnership(root, nil, func(n *chapi.OwnershipNode, above []*chapi.OwnershipNode) {
+		id, isNew := nodeID(n, above)
+		if isNew {
+			label := dotEscape(n.Name)
+			if n.CompanyNumber != "" {
+				label += `\n` + dotEscape(n.CompanyNumber)
+			} else if n.CountryRegistered != "" {
+				label += `\n` + dotEscape(n.CountryRegistered)
+			}
+			attrs := ""
+			switch n.Stop {
+			case chapi.ChainStopIndividual:
+				attrs = ", shape=ellipse"
+			case chapi.ChainStopForeign, chapi.ChainStopUnidentified, chapi.ChainStopDepth, chapi.ChainStopNotFound, chapi.ChainStopError:
+				attrs = ", style=dashed"
+			}
+			fmt.Fprintf(w, "  %s [label=\"%s\"%s];\n", id, label, attrs)
+		}
+		if len(above) == 0 {
+			return
+		}
+		parent, _ := nodeID(above[len(above)-1], above[:len(above)-1])
+		edge := id + " -> " + parent
+		if edges[edge] {
+			return
+		}
+		edges[edge] = true
+		label := "control"
+		if n.Ownership != nil {
+			label = n.Ownership.String()
+		}
+		fmt.Fprintf(w, "  %s [label=\"%s\"];\n", edge, label)
+	})
+	fmt.Fprintln(w, "}")
+}
+
+// dotEscape escapes backslashes and double quotes for a DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
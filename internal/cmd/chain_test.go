@@ -0,0 +1,72 @@
+package cmd_test
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/chfake"
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_PSCChain(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"psc", "chain", "12987654"},
+		{"psc", "chain", "12987654", "--format", "dot"},
+		{"psc", "chain", "12987654", "--depth", "1", "--json"},
+		{"psc", "chain", "OE012345"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+
+	err := cmd.Execute([]string{"psc", "chain", "12987654", "--depth", "0"})
+	if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+	}
+	err = cmd.Execute([]string{"psc", "chain", "99999999"})
+	if cmd.ExitCode(err) != cmd.ExitCodeNotFound {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeNotFound, err)
+	}
+}
+
+func TestExecute_PSCChainDOTKeepsNamesakesApart(t *testing.T) {
+	useFake(t)
+	individual := chapi.PSC{
+		Name:             "JANE SMITH",
+		Kind:             "individual-person-with-significant-control",
+		NaturesOfControl: []string{"ownership-of-shares-25-to-50-percent"},
+	}
+	fake := chfake.New(chfake.Dataset{})
+	fake.AddCompany(chfake.Company{
+		Profile: chapi.CompanyProfile{CompanyNumber: "SC100001", CompanyName: "PARENT LTD"},
+		PSCs: []chapi.PSC{individual, {
+			Name:             "CHILD HOLDINGS LIMITED",
+			Kind:             "corporate-entity-person-with-significant-control",
+			NaturesOfControl: []string{"ownership-of-shares-50-to-75-percent"},
+			Identification:   &chapi.PSCIdentification{CountryRegistered: "Scotland", RegistrationNumber: "SC100002"},
+		}},
+	})
+	fake.AddCompany(chfake.Company{
+		Profile: chapi.CompanyProfile{CompanyNumber: "SC100002", CompanyName: "CHILD HOLDINGS LIMITED"},
+		PSCs:    []chapi.PSC{individual},
+	})
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+	t.Setenv("CH_BASE_URL", srv.URL)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = cmd.Execute([]string{"psc", "chain", "SC100001", "--format", "dot", "--no-cache"})
+	})
+	if err != nil {
+		t.Fatalf("psc chain error: %v", err)
+	}
+	if got := strings.Count(out, `[label="JANE SMITH"`); got != 2 {
+		t.Errorf("JANE SMITH drawn %d times, want 2 (one per company):\n%s", got, out)
+	}
+}
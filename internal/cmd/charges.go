@@ -4,15 +4,16 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
 )
 
 // ChargesCmd retrieves company charges.
 type ChargesCmd struct {
 	List ChargesListCmd `cmd:"" help:"List charges for a company"`
+	Get  ChargesGetCmd  `cmd:"" help:"Get the full record for one charge"`
 }
 
 // ChargesListCmd lists charges for a company.
@@ -20,20 +21,25 @@ type ChargesListCmd struct {
 	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
 	ItemsPerPage  int    `help:"Results per page" default:"25"`
 	StartIndex    int    `help:"Start index for pagination" default:"0"`
+	All           bool   `help:"Fetch every page of results"`
+	Limit         int    `help:"Stop after N results (implies --all)"`
 }
 
-func (c *ChargesListCmd) Run(ctx context.Context) error {
+func (c *ChargesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
-	result, err := client.ListCharges(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	var result *chapi.ChargeList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllCharges(ctx, cn, c.Limit)
+	} else {
+		result, err = client.ListCharges(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	}
 	if err != nil {
 		return fmt.Errorf("list charges: %w", err)
 	}
@@ -48,3 +54,110 @@ func (c *ChargesListCmd) Run(ctx context.Context) error {
 	}
 	return nil
 }
+
+// ChargesGetCmd retrieves a single charge.
+type ChargesGetCmd struct {
+	CompanyNumber string `arg:"" help:"Company number"`
+	ChargeID      string `arg:"" help:"Charge ID or self link from charges list --json"`
+}
+
+func (c *ChargesGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	id := c.ChargeID
+	if linkID := chapi.ChargeIDFromLink(id); linkID != "" {
+		id = linkID
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	ch, err := client.GetCharge(ctx, cn, id)
+	if err != nil {
+		return fmt.Errorf("get charge: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, ch)
+	}
+
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Charge Code:", ch.ChargeCode)
+	if ch.ChargeNumber > 0 {
+		fmt.Fprintf(os.Stdout, "%-20s %d\n", "Charge Number:", ch.ChargeNumber)
+	}
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Status:", ch.Status)
+	if ch.Classification.Description != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Classification:", ch.Classification.Description)
+	}
+	for _, d := range []struct{ label, value string }{
+		{"Created:", ch.CreatedOn},
+		{"Delivered:", ch.DeliveredOn},
+		{"Acquired:", ch.AcquiredOn},
+		{"Satisfied:", ch.SatisfiedOn},
+		{"Resolved:", ch.ResolvedOn},
+		{"Instrument Dated:", ch.CoveringInstrumentDate},
+		{"Assets Released:", ch.AssetsCeasedReleased},
+	} {
+		if d.value != "" {
+			fmt.Fprintf(os.Stdout, "%-20s %s\n", d.label, d.value)
+		}
+	}
+
+	names := make([]string, 0, len(ch.PersonsEntitled))
+	for _, p := range ch.PersonsEntitled {
+		names = append(names, p.Name)
+	}
+	if ch.MoreThanFourPersonsEntitled {
+		names = append(names, "and others")
+	}
+	if len(names) > 0 {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Persons Entitled:", strings.Join(names, ", "))
+	}
+
+	if p := ch.Particulars; p != nil {
+		fmt.Fprintf(os.Stdout, "\nParticulars\n")
+		if p.Description != "" {
+			fmt.Fprintf(os.Stdout, "  %s\n", p.Description)
+		}
+		var features []string
+		for _, f := range []struct {
+			set  bool
+			name string
+		}{
+			{p.ContainsFixedCharge, "fixed charge"},
+			{p.ContainsFloatingCharge, "floating charge"},
+			{p.FloatingChargeCoversAll, "floating charge covers all"},
+			{p.ContainsNegativePledge, "negative pledge"},
+			{p.ChargorActingAsBareTrustee, "chargor acting as bare trustee"},
+		} {
+			if f.set {
+				features = append(features, f.name)
+			}
+		}
+		if len(features) > 0 {
+			fmt.Fprintf(os.Stdout, "  %-18s %s\n", "Contains:", strings.Join(features, "; "))
+		}
+	}
+
+	if d := ch.SecuredDetails; d != nil {
+		fmt.Fprintf(os.Stdout, "\nSecured (%s)\n  %s\n", d.Type, d.Description)
+	}
+
+	if len(ch.Transactions) > 0 {
+		fmt.Fprintf(os.Stdout, "\nTransactions\n")
+		for _, t := range ch.Transactions {
+			line := fmt.Sprintf("  %-12s  %-40s  %s", t.DeliveredOn, t.FilingType, t.Links.Filing)
+			fmt.Fprintln(os.Stdout, strings.TrimRight(line, " "))
+		}
+	}
+
+	if len(ch.InsolvencyCases) > 0 {
+		fmt.Fprintf(os.Stdout, "\nInsolvency Cases\n")
+		for _, ic := range ch.InsolvencyCases {
+			fmt.Fprintf(os.Stdout, "  Case %d\n", ic.CaseNumber)
+		}
+	}
+	return nil
+}
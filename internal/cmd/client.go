@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/anthonyencodeclub/ch/internal/cache"
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/config"
+)
+
+// newClient returns a Companies House API client configured from the root
+// flags. In offline and replay modes a missing API key is tolerated, since no
+// request reaches the network. Recording and replaying bypass the cache so
+// every exchange goes through the cassette.
+func newClient(flags *RootFlags) (*chapi.Client, error) {
+	apiKey, err := resolveAPIKey()
+	if err != nil && !flags.Offline && flags.Replay == "" {
+		return nil, err
+	}
+
+	opts := clientOptions(flags)
+	if !flags.NoCache && flags.Record == "" && flags.Replay == "" {
+		store, err := openCache()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, chapi.WithCache(chapi.CacheOptions{
+			Store:   store,
+			TTL:     flags.CacheTTL,
+			Offline: flags.Offline,
+		}))
+	}
+	return chapi.New(apiKey, opts...), nil
+}
+
+// newFilingClient returns a filing API client configured from the root flags.
+func newFilingClient(flags *RootFlags, accessToken string) *chapi.FilingClient {
+	return chapi.NewFilingClient(accessToken, clientOptions(flags)...)
+}
+
+// newDocumentClient returns a Document API client configured from the root
+// flags.
+func newDocumentClient(flags *RootFlags) (*chapi.DocumentClient, error) {
+	apiKey, err := resolveAPIKey()
+	if err != nil && flags.Replay == "" {
+		return nil, err
+	}
+	opts := clientOptions(flags)
+	if flags.DocumentURL != "" {
+		opts = append(opts, chapi.WithBaseURL(flags.DocumentURL))
+	}
+	return chapi.NewDocumentClient(apiKey, opts...), nil
+}
+
+// newStreamClient returns a Streaming API client configured from the root
+// flags. key is the stream key; if empty the API key is used.
+func newStreamClient(flags *RootFlags, key string) (*chapi.StreamClient, error) {
+	if key == "" {
+		var err error
+		if key, err = resolveAPIKey(); err != nil {
+			return nil, err
+		}
+	}
+	opts := clientOptions(flags)
+	if flags.StreamURL != "" {
+		opts = append(opts, chapi.WithBaseURL(flags.StreamURL))
+	}
+	return chapi.NewStreamClient(key, opts...), nil
+}
+
+// resolveAPIKey returns the configured API key. A missing key exits with
+// ExitCodeAuth, like a key the API rejects.
+func resolveAPIKey() (string, error) {
+	key, err := config.APIKey()
+	if errors.Is(err, config.ErrNoAPIKey) {
+		return "", &ExitError{Code: ExitCodeAuth, Err: err}
+	}
+	return key, err
+}
+
+// clientOptions translates the connection-related root flags into client
+// options shared by the public data and filing clients.
+func clientOptions(flags *RootFlags) []chapi.Option {
+	opts := []chapi.Option{
+		chapi.WithUserAgent("ch/" + strings.TrimSpace(version)),
+		chapi.WithTimeout(flags.Timeout),
+		chapi.WithRetries(flags.Retries),
+	}
+	if flags.BaseURL != "" {
+		opts = append(opts, chapi.WithBaseURL(flags.BaseURL))
+	}
+	if flags.transport != nil {
+		opts = append(opts, chapi.WithTransport(flags.transport))
+	}
+	return opts
+}
+
+// openCache opens the response cache under the config directory.
+func openCache() (*cache.Store, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return cache.Open(dir)
+}
@@ -1,13 +1,16 @@
 package cmd
 
 import (
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"slices"
 	"strings"
 
 	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
 )
 
@@ -15,6 +18,12 @@ import (
 type CompanyCmd struct {
 	Get     CompanyGetCmd     `cmd:"" help:"Get company profile"`
 	Address CompanyAddressCmd `cmd:"" help:"Get registered office address"`
+
+	Registers      CompanyRegistersCmd      `cmd:"" help:"Where the statutory registers are kept"`
+	Exemptions     CompanyExemptionsCmd     `cmd:"" help:"PSC disclosure exemptions"`
+	Establishments CompanyEstablishmentsCmd `cmd:"" help:"UK establishments of an overseas company"`
+
+	Report CompanyReportCmd `cmd:"" help:"Due-diligence report: profile, officers, PSCs, charges, insolvency and recent filings"`
 }
 
 // CompanyGetCmd retrieves a company profile.
@@ -22,17 +31,15 @@ type CompanyGetCmd struct {
 	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
 }
 
-func (c *CompanyGetCmd) Run(ctx context.Context) error {
+func (c *CompanyGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
 	profile, err := client.GetCompany(ctx, cn)
 	if err != nil {
 		return fmt.Errorf("get company: %w", err)
@@ -80,17 +87,15 @@ type CompanyAddressCmd struct {
 	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
 }
 
-func (c *CompanyAddressCmd) Run(ctx context.Context) error {
+func (c *CompanyAddressCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
 	addr, err := client.GetRegisteredOffice(ctx, cn)
 	if err != nil {
 		return fmt.Errorf("get address: %w", err)
@@ -122,3 +127,156 @@ func (c *CompanyAddressCmd) Run(ctx context.Context) error {
 	fmt.Fprintln(os.Stdout, strings.Join(parts, ", "))
 	return nil
 }
+
+// CompanyRegistersCmd shows where a company keeps its statutory registers.
+type CompanyRegistersCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+}
+
+func (c *CompanyRegistersCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	// A 404 for a company that exists means no register has ever been
+	// moved: they are all kept at the registered office.
+	result, err := client.GetRegisters(ctx, cn)
+	if err = noneRecorded(ctx, client, cn, err); err == nil && result == nil {
+		result = &chapi.CompanyRegisters{CompanyNumber: cn, Registers: map[string]chapi.Register{}}
+	}
+	if err != nil {
+		return fmt.Errorf("get registers: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	if len(result.Registers) == 0 {
+		fmt.Fprintln(os.Stdout, "All registers are held at the registered office")
+		return nil
+	}
+
+	names := slices.Collect(maps.Keys(result.Registers))
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Compare(registerOrder(a), registerOrder(b))
+	})
+	for _, name := range names {
+		reg := result.Registers[name]
+		loc, ok := reg.Current()
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(os.Stdout, "%-36s %-40s since %s\n", strings.ReplaceAll(name, "_", " ")+":", loc.RegisterMovedTo, loc.MovedOn)
+	}
+	return nil
+}
+
+// noneRecorded clears a not-found error from a company sub-resource when the
+// company itself exists, since the API answers 404 for a resource that has
+// nothing recorded. For an unknown company the profile's not-found error is
+// returned instead, so it is not mistaken for an empty record.
+func noneRecorded(ctx context.Context, client *chapi.Client, companyNumber string, err error) error {
+	if !errors.Is(err, chapi.ErrNotFound) {
+		return err
+	}
+	if _, perr := client.GetCompany(ctx, companyNumber); perr != nil {
+		return perr
+	}
+	return nil
+}
+
+// registerOrder ranks register names as chapi.RegisterTypes does, with
+// unrecognised names last.
+func registerOrder(name string) int {
+	if i := slices.Index(chapi.RegisterTypes, name); i >= 0 {
+		return i
+	}
+	return len(chapi.RegisterTypes)
+}
+
+// CompanyExemptionsCmd lists a company's PSC disclosure exemptions.
+type CompanyExemptionsCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+}
+
+func (c *CompanyExemptionsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	result, err := client.GetExemptions(ctx, cn)
+	if err = noneRecorded(ctx, client, cn, err); err == nil && result == nil {
+		result = &chapi.CompanyExemptions{Exemptions: map[string]chapi.Exemption{}}
+	}
+	if err != nil {
+		return fmt.Errorf("get exemptions: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	if len(result.Exemptions) == 0 {
+		fmt.Fprintln(os.Stdout, "No PSC disclosure exemptions")
+		return nil
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(result.Exemptions)) {
+		ex := result.Exemptions[name]
+		status := "ended"
+		if ex.Active() {
+			status = "active"
+		}
+		fmt.Fprintf(os.Stdout, "%s (%s)\n", strings.ReplaceAll(name, "_", " "), status)
+		for _, p := range ex.Items {
+			to := p.ExemptTo
+			if to == "" {
+				to = "present"
+			}
+			fmt.Fprintf(os.Stdout, "  %s to %s\n", p.ExemptFrom, to)
+		}
+	}
+	return nil
+}
+
+// CompanyEstablishmentsCmd lists the UK establishments of an overseas company.
+type CompanyEstablishmentsCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Overseas company number, e.g. FC031234 (uses default if omitted)"`
+}
+
+func (c *CompanyEstablishmentsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	result, err := client.ListUKEstablishments(ctx, cn)
+	if err != nil {
+		return fmt.Errorf("list uk establishments: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	if len(result.Items) == 0 {
+		fmt.Fprintln(os.Stdout, "No UK establishments")
+		return nil
+	}
+	for _, e := range result.Items {
+		fmt.Fprintf(os.Stdout, "  %-10s  %-50s  %-10s  %s\n", e.CompanyNumber, e.CompanyName, e.CompanyStatus, e.Locality)
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chfake"
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_CompanyKYC(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"company", "registers", "12987654"},
+		{"company", "exemptions", "00445790", "--json"},
+		{"company", "exemptions", "12987654", "--json"},
+		{"company", "establishments", "FC031234"},
+		{"company", "establishments", "00445790"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+
+	for args, want := range map[string]string{
+		"registers":  "All registers are held at the registered office",
+		"exemptions": "No PSC disclosure exemptions",
+	} {
+		var err error
+		out := captureStdout(t, func() {
+			err = cmd.Execute([]string{"company", args, "13876501"})
+		})
+		if err != nil {
+			t.Errorf("company %s error: %v", args, err)
+		}
+		if !strings.Contains(out, want) {
+			t.Errorf("company %s output = %q, want %q", args, out, want)
+		}
+	}
+
+	// An unknown company is not found, not free of registers or exemptions.
+	for _, sub := range []string{"registers", "exemptions"} {
+		err := cmd.Execute([]string{"company", sub, "99999999"})
+		if cmd.ExitCode(err) != cmd.ExitCodeNotFound {
+			t.Errorf("company %s ExitCode = %d, want %d (err: %v)", sub, cmd.ExitCode(err), cmd.ExitCodeNotFound, err)
+		}
+	}
+}
+
+func TestExecute_CompanyReportFormats(t *testing.T) {
+	useFake(t)
+	dir := t.TempDir()
+
+	for format, want := range map[string]string{
+		"text":     "Insolvency (liquidation):",
+		"markdown": "## Charges (1 total, 0 satisfied)",
+		"html":     "<h1>OLD MILL TRADING LIMITED (04567321)</h1>",
+		"json":     `"wound-up-on"`,
+	} {
+		out := filepath.Join(dir, "report."+format)
+		if err := cmd.Execute([]string{"company", "report", "04567321", "--format", format, "-o", out}); err != nil {
+			t.Fatalf("report --format %s error: %v", format, err)
+		}
+		b, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(b), want) {
+			t.Errorf("%s report missing %q:\n%s", format, want, b)
+		}
+	}
+
+	err := cmd.Execute([]string{"company", "report", "99999999"})
+	if cmd.ExitCode(err) != cmd.ExitCodeNotFound {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeNotFound, err)
+	}
+}
+
+func TestExecute_CompanyReportPartialFailure(t *testing.T) {
+	useFake(t)
+	fake := chfake.New(chfake.DefaultDataset())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/officers") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fake.ServeHTTP(w, r)
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("CH_BASE_URL", srv.URL)
+
+	out := filepath.Join(t.TempDir(), "report.json")
+	if err := cmd.Execute([]string{"company", "report", "00445790", "--format", "json", "-o", out}); err != nil {
+		t.Fatalf("report error: %v", err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report cmd.CompanyReport
+	if err := json.Unmarshal(b, &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Officers != nil || report.Errors["officers"] == "" {
+		t.Errorf("officers = %+v, errors = %v; want the officers section to have failed", report.Officers, report.Errors)
+	}
+	if report.Profile == nil || report.Charges == nil || len(report.Filings.Items) == 0 {
+		t.Error("sections other than officers should still be filled in")
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+
+	"github.com/anthonyencodeclub/ch/internal/chfake"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// DevCmd contains tools for local development.
+type DevCmd struct {
+	Serve DevServeCmd `cmd:"" help:"Run a fake Companies House API server backed by fixture data"`
+}
+
+// DevServeCmd runs the fake API server until interrupted.
+type DevServeCmd struct {
+	Addr string `help:"Address to listen on" default:"127.0.0.1:8080"`
+	Data string `help:"Fixture dataset JSON file (uses the built-in dataset if omitted)" type:"existingfile"`
+}
+
+func (c *DevServeCmd) Run(ctx context.Context) error {
+	ds := chfake.DefaultDataset()
+	if c.Data != "" {
+		var err error
+		if ds, err = chfake.LoadDataset(c.Data); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("tcp", c.Addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	baseURL := "http://" + ln.Addr().String()
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(os.Stdout, map[string]any{
+			"base_url":   baseURL,
+			"stream_url": baseURL + chfake.StreamPath,
+			"companies":  len(ds.Companies),
+		}); err != nil {
+			return err
+		}
+	} else if u := ui.FromContext(ctx); u != nil {
+		u.Success(fmt.Sprintf("Fake Companies House API listening on %s (%d companies)", baseURL, len(ds.Companies)))
+		u.Info(fmt.Sprintf("Point ch at it with: export CH_BASE_URL=%s CH_DOCUMENT_URL=%s CH_STREAM_URL=%s%s CH_API_KEY=dev",
+			baseURL, baseURL, baseURL, chfake.StreamPath))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	// Requests share ctx so open streams end on interrupt instead of
+	// holding up Shutdown.
+	srv := &http.Server{
+		Handler:     chfake.New(ds),
+		BaseContext: func(net.Listener) context.Context { return ctx },
+	}
+	go func() {
+		<-ctx.Done()
+		srv.Shutdown(context.Background())
+	}()
+	if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serve: %w", err)
+	}
+	return nil
+}
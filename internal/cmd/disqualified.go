@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+)
+
+// DisqualifiedCmd retrieves disqualified officer records.
+type DisqualifiedCmd struct {
+	Get DisqualifiedGetCmd `cmd:"" help:"Get a disqualified officer's disqualifications and permissions"`
+}
+
+// DisqualifiedGetCmd retrieves one disqualified officer.
+type DisqualifiedGetCmd struct {
+	OfficerID string `arg:"" help:"Officer ID or /disqualified-officers/... link from search results"`
+	Kind      string `help:"Officer kind; auto tries natural, then corporate" enum:"auto,natural,corporate" default:"auto"`
+}
+
+func (c *DisqualifiedGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	kind, id := c.Kind, c.OfficerID
+	if k, linkID, ok := chapi.ParseDisqualifiedOfficerLink(id); ok {
+		kind, id = k, linkID
+	}
+
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+
+	if kind == "auto" || kind == "natural" {
+		officer, err := client.GetNaturalDisqualifiedOfficer(ctx, id)
+		switch {
+		case err == nil:
+			if outfmt.IsJSON(ctx) {
+				return outfmt.WriteJSON(os.Stdout, officer)
+			}
+			fmt.Fprintf(os.Stdout, "%-20s %s\n", "Name:", officer.Name())
+			if officer.DateOfBirth != "" {
+				fmt.Fprintf(os.Stdout, "%-20s %s\n", "Date of Birth:", officer.DateOfBirth)
+			}
+			if officer.Nationality != "" {
+				fmt.Fprintf(os.Stdout, "%-20s %s\n", "Nationality:", officer.Nationality)
+			}
+			printDisqualifications(officer.Disqualifications, officer.PermissionsToAct)
+			return nil
+		case kind == "natural" || !errors.Is(err, chapi.ErrNotFound):
+			return fmt.Errorf("get disqualified officer: %w", err)
+		}
+	}
+
+	officer, err := client.GetCorporateDisqualifiedOfficer(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get disqualified officer: %w", err)
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, officer)
+	}
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Name:", officer.Name)
+	if officer.CompanyNumber != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Company Number:", officer.CompanyNumber)
+	}
+	if officer.CountryOfRegistration != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Registered In:", officer.CountryOfRegistration)
+	}
+	printDisqualifications(officer.Disqualifications, officer.PermissionsToAct)
+	return nil
+}
+
+func printDisqualifications(ds []chapi.Disqualification, perms []chapi.PermissionToAct) {
+	for i, d := range ds {
+		fmt.Fprintf(os.Stdout, "\nDisqualification %d (%s)\n", i+1, d.DisqualificationType)
+		fmt.Fprintf(os.Stdout, "  %-20s %s to %s\n", "Period:", d.DisqualifiedFrom, d.DisqualifiedUntil)
+		if d.HeardOn != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Heard On:", d.HeardOn)
+		}
+		if d.UndertakenOn != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Undertaken On:", d.UndertakenOn)
+		}
+		if d.CourtName != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Court:", d.CourtName)
+		}
+		if d.CaseIdentifier != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Case:", d.CaseIdentifier)
+		}
+		reason := d.Reason.Act
+		if d.Reason.Section != "" {
+			reason += " s." + d.Reason.Section
+		}
+		if d.Reason.Article != "" {
+			reason += " art." + d.Reason.Article
+		}
+		fmt.Fprintf(os.Stdout, "  %-20s %s (%s)\n", "Reason:", reason, d.Reason.DescriptionIdentifier)
+		if len(d.CompanyNames) > 0 {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Companies:", strings.Join(d.CompanyNames, ", "))
+		}
+		addr := formatAddress(d.Address.RegisteredOffice)
+		if d.Address.Premises != "" {
+			addr = strings.TrimSuffix(d.Address.Premises+", "+addr, ", ")
+		}
+		if addr != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Address:", addr)
+		}
+		for _, v := range d.LastVariation {
+			fmt.Fprintf(os.Stdout, "  %-20s %s (%s)\n", "Varied On:", v.VariedOn, v.CourtName)
+		}
+	}
+	for _, p := range perms {
+		fmt.Fprintf(os.Stdout, "\nPermission to act granted %s", p.GrantedOn)
+		if p.ExpiresOn != "" {
+			fmt.Fprintf(os.Stdout, ", expires %s", p.ExpiresOn)
+		}
+		fmt.Fprintln(os.Stdout)
+		if len(p.CompanyNames) > 0 {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Companies:", strings.Join(p.CompanyNames, ", "))
+		}
+		if p.CourtName != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Court:", p.CourtName)
+		}
+		if p.Purpose != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s %s\n", "Purpose:", p.Purpose)
+		}
+	}
+}
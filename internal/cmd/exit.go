@@ -1,6 +1,23 @@
 package cmd
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// Exit codes returned by the ch binary. Scripts can rely on these to tell a
+// missing company from a bad API key from a transient failure.
+const (
+	ExitCodeOK          = 0
+	ExitCodeGeneric     = 1 // any error not listed below
+	ExitCodeUsage       = 2 // invalid flags or arguments
+	ExitCodeNotFound    = 3 // the requested resource does not exist (HTTP 404)
+	ExitCodeAuth        = 4 // missing or rejected credentials (HTTP 401/403)
+	ExitCodeRateLimited = 5 // rate limit still exceeded after retries (HTTP 429)
+	ExitCodeValidation  = 6 // the API rejected the request as invalid (HTTP 400/422)
+	ExitCodeServer      = 7 // Companies House returned a server error (HTTP 5xx)
+)
 
 // ExitError wraps an error with a specific exit code.
 type ExitError struct {
@@ -25,14 +42,26 @@ func (e *ExitError) Unwrap() error {
 // ExitCode returns the exit code for an error.
 func ExitCode(err error) int {
 	if err == nil {
-		return 0
+		return ExitCodeOK
 	}
 	var ee *ExitError
 	if errors.As(err, &ee) && ee != nil {
 		if ee.Code < 0 {
-			return 1
+			return ExitCodeGeneric
 		}
 		return ee.Code
 	}
-	return 1
+	switch {
+	case errors.Is(err, chapi.ErrNotFound):
+		return ExitCodeNotFound
+	case errors.Is(err, chapi.ErrUnauthorized):
+		return ExitCodeAuth
+	case errors.Is(err, chapi.ErrRateLimited):
+		return ExitCodeRateLimited
+	case errors.Is(err, chapi.ErrValidation):
+		return ExitCodeValidation
+	case errors.Is(err, chapi.ErrServer):
+		return ExitCodeServer
+	}
+	return ExitCodeGeneric
 }
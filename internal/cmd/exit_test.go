@@ -2,8 +2,10 @@ package cmd_test
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
+	"github.com/anthonyencodeclub/ch/internal/chapi"
 	"github.com/anthonyencodeclub/ch/internal/cmd"
 )
 
@@ -34,6 +36,27 @@ func TestExitCode_GenericError(t *testing.T) {
 	}
 }
 
+func TestExitCode_APIErrors(t *testing.T) {
+	tests := []struct {
+		status int
+		want   int
+	}{
+		{404, cmd.ExitCodeNotFound},
+		{401, cmd.ExitCodeAuth},
+		{403, cmd.ExitCodeAuth},
+		{429, cmd.ExitCodeRateLimited},
+		{400, cmd.ExitCodeValidation},
+		{503, cmd.ExitCodeServer},
+		{409, cmd.ExitCodeGeneric},
+	}
+	for _, tt := range tests {
+		err := fmt.Errorf("get company: %w", &chapi.APIError{StatusCode: tt.status})
+		if got := cmd.ExitCode(err); got != tt.want {
+			t.Errorf("ExitCode(HTTP %d) = %d, want %d", tt.status, got, tt.want)
+		}
+	}
+}
+
 func TestExitError_Error(t *testing.T) {
 	err := &cmd.ExitError{Code: 1, Err: errors.New("something failed")}
 	if got := err.Error(); got != "something failed" {
@@ -28,7 +28,7 @@ type FileAddressCmd struct {
 	Country       string `help:"Country"`
 }
 
-func (c *FileAddressCmd) Run(ctx context.Context) error {
+func (c *FileAddressCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
@@ -40,7 +40,7 @@ func (c *FileAddressCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	client := chapi.NewFilingClient(accessToken)
+	client := newFilingClient(flags, accessToken)
 
 	// Step 1: Create transaction
 	if u != nil {
@@ -119,7 +119,7 @@ type FileEmailCmd struct {
 	Email         string `required:"" help:"New registered email address"`
 }
 
-func (c *FileEmailCmd) Run(ctx context.Context) error {
+func (c *FileEmailCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
@@ -131,7 +131,7 @@ func (c *FileEmailCmd) Run(ctx context.Context) error {
 		return err
 	}
 
-	client := chapi.NewFilingClient(accessToken)
+	client := newFilingClient(flags, accessToken)
 
 	// Step 1: Create transaction
 	if u != nil {
@@ -3,17 +3,21 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
 )
 
 // FilingCmd retrieves filing history.
 type FilingCmd struct {
-	List FilingListCmd `cmd:"" help:"List filing history for a company"`
-	Get  FilingGetCmd  `cmd:"" help:"Get a specific filing"`
+	List     FilingListCmd     `cmd:"" help:"List filing history for a company"`
+	Get      FilingGetCmd      `cmd:"" help:"Get a specific filing"`
+	Download FilingDownloadCmd `cmd:"" help:"Download the document behind a filing (PDF or XHTML)"`
+	Archive  FilingArchiveCmd  `cmd:"" help:"Download every matching filing document with a checksummed manifest"`
 }
 
 // FilingListCmd lists filing history.
@@ -22,20 +26,25 @@ type FilingListCmd struct {
 	Category      string `help:"Filter by category (e.g. accounts, confirmation-statement)" default:""`
 	ItemsPerPage  int    `help:"Results per page" default:"25"`
 	StartIndex    int    `help:"Start index for pagination" default:"0"`
+	All           bool   `help:"Fetch every page of results"`
+	Limit         int    `help:"Stop after N results (implies --all)"`
 }
 
-func (c *FilingListCmd) Run(ctx context.Context) error {
+func (c *FilingListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
-	result, err := client.ListFilingHistory(ctx, cn, c.Category, c.ItemsPerPage, c.StartIndex)
+	var result *chapi.FilingHistoryList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllFilingHistory(ctx, cn, c.Category, c.Limit)
+	} else {
+		result, err = client.ListFilingHistory(ctx, cn, c.Category, c.ItemsPerPage, c.StartIndex)
+	}
 	if err != nil {
 		return fmt.Errorf("list filings: %w", err)
 	}
@@ -57,13 +66,11 @@ type FilingGetCmd struct {
 	TransactionID string `arg:"" help:"Transaction ID"`
 }
 
-func (c *FilingGetCmd) Run(ctx context.Context) error {
-	apiKey, err := config.APIKey()
+func (c *FilingGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
 	item, err := client.GetFilingHistoryItem(ctx, c.CompanyNumber, c.TransactionID)
 	if err != nil {
 		return fmt.Errorf("get filing: %w", err)
@@ -80,3 +87,114 @@ func (c *FilingGetCmd) Run(ctx context.Context) error {
 	fmt.Fprintf(os.Stdout, "%-15s %s\n", "Description:", item.Description)
 	return nil
 }
+
+// documentFormats maps --format values to Document API content types and
+// file extensions.
+var documentFormats = map[string]struct{ contentType, ext string }{
+	"pdf":   {chapi.ContentTypePDF, ".pdf"},
+	"xhtml": {chapi.ContentTypeXHTML, ".xhtml"},
+}
+
+// FilingDownloadCmd downloads a filing's document.
+type FilingDownloadCmd struct {
+	CompanyNumber string `arg:"" help:"Company number"`
+	TransactionID string `arg:"" help:"Transaction ID"`
+	Output        string `short:"o" help:"Write to FILE, or - for stdout (default: <company>-<transaction>.<ext>)" placeholder:"FILE"`
+	Format        string `help:"Document format: pdf|xhtml" enum:"pdf,xhtml" default:"pdf"`
+}
+
+// downloadResult is the JSON output of a download.
+type downloadResult struct {
+	CompanyNumber string `json:"company_number"`
+	TransactionID string `json:"transaction_id"`
+	DocumentID    string `json:"document_id"`
+	ContentType   string `json:"content_type"`
+	Path          string `json:"path"`
+	Bytes         int64  `json:"bytes"`
+}
+
+func (c *FilingDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	docs, err := newDocumentClient(flags)
+	if err != nil {
+		return err
+	}
+	format := documentFormats[c.Format]
+
+	item, err := client.GetFilingHistoryItem(ctx, c.CompanyNumber, c.TransactionID)
+	if err != nil {
+		return fmt.Errorf("get filing: %w", err)
+	}
+	docID := chapi.DocumentIDFromLink(item.Links["document_metadata"])
+	if docID == "" {
+		return fmt.Errorf("filing %s has no document available", c.TransactionID)
+	}
+	meta, err := docs.GetDocumentMetadata(ctx, docID)
+	if err != nil {
+		return fmt.Errorf("get document metadata: %w", err)
+	}
+	if !meta.HasFormat(format.contentType) {
+		return fmt.Errorf("filing %s is not available as %s", c.TransactionID, c.Format)
+	}
+
+	path := c.Output
+	if path == "" {
+		path = c.CompanyNumber + "-" + c.TransactionID + format.ext
+	}
+	var n int64
+	if path == "-" {
+		n, err = docs.DownloadDocument(ctx, docID, format.contentType, os.Stdout)
+	} else {
+		n, err = writeFileAtomic(path, func(w io.Writer) (int64, error) {
+			return docs.DownloadDocument(ctx, docID, format.contentType, w)
+		})
+	}
+	if err != nil {
+		return fmt.Errorf("download document: %w", err)
+	}
+	if path == "-" {
+		return nil
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, downloadResult{
+			CompanyNumber: c.CompanyNumber,
+			TransactionID: c.TransactionID,
+			DocumentID:    docID,
+			ContentType:   format.contentType,
+			Path:          path,
+			Bytes:         n,
+		})
+	}
+	if u := ui.FromContext(ctx); u != nil {
+		u.Success(fmt.Sprintf("Saved %s (%d bytes)", path, n))
+	}
+	return nil
+}
+
+// writeFileAtomic writes path through a temporary file in the same
+// directory, so an interrupted download never leaves a truncated file behind.
+func writeFileAtomic(path string, write func(io.Writer) (int64, error)) (int64, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return 0, err
+	}
+	n, err := write(tmp)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Chmod(tmp.Name(), 0o644)
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return n, err
+	}
+	return n, nil
+}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
 )
 
@@ -20,17 +18,15 @@ type InsolvencyGetCmd struct {
 	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
 }
 
-func (c *InsolvencyGetCmd) Run(ctx context.Context) error {
+func (c *InsolvencyGetCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
 	result, err := client.GetInsolvency(ctx, cn)
 	if err != nil {
 		return fmt.Errorf("get insolvency: %w", err)
@@ -4,15 +4,19 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
 )
 
 // OfficersCmd lists and views company officers.
 type OfficersCmd struct {
-	List OfficersListCmd `cmd:"" help:"List officers for a company"`
+	List         OfficersListCmd         `cmd:"" help:"List officers for a company"`
+	Get          OfficersGetCmd          `cmd:"" help:"Get the full record for one officer appointment"`
+	Appointments OfficersAppointmentsCmd `cmd:"" help:"List an officer's appointments across all companies"`
 }
 
 // OfficersListCmd lists officers for a company.
@@ -20,20 +24,25 @@ type OfficersListCmd struct {
 	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
 	ItemsPerPage  int    `help:"Results per page" default:"50"`
 	StartIndex    int    `help:"Start index for pagination" default:"0"`
+	All           bool   `help:"Fetch every page of results"`
+	Limit         int    `help:"Stop after N results (implies --all)"`
 }
 
-func (c *OfficersListCmd) Run(ctx context.Context) error {
+func (c *OfficersListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
-	result, err := client.ListOfficers(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	var result *chapi.OfficerList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllOfficers(ctx, cn, c.Limit)
+	} else {
+		result, err = client.ListOfficers(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	}
 	if err != nil {
 		return fmt.Errorf("list officers: %w", err)
 	}
@@ -48,7 +57,170 @@ func (c *OfficersListCmd) Run(ctx context.Context) error {
 		if o.ResignedOn != "" {
 			resigned = fmt.Sprintf(" (resigned %s)", o.ResignedOn)
 		}
-		fmt.Fprintf(os.Stdout, "  %-40s  %-20s  appointed %s%s\n", o.Name, o.OfficerRole, o.AppointedOn, resigned)
+		fmt.Fprintf(os.Stdout, "  %-40s  %-20s  %-27s  appointed %s%s\n", o.Name, o.OfficerRole, o.AppointmentID(), o.AppointedOn, resigned)
+	}
+	return nil
+}
+
+// OfficersGetCmd retrieves a single officer appointment.
+type OfficersGetCmd struct {
+	CompanyNumber string `arg:"" help:"Company number"`
+	AppointmentID string `arg:"" help:"Appointment ID (as shown by officers list)"`
+}
+
+func (c *OfficersGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	o, err := client.GetOfficerAppointment(ctx, cn, c.AppointmentID)
+	if err != nil {
+		return fmt.Errorf("get officer: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, o)
+	}
+
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Name:", o.Name)
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Role:", o.OfficerRole)
+	appointed := o.AppointedOn
+	if o.IsPre1992Appointment {
+		appointed += " (pre-1992 appointment)"
+	}
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Appointed:", appointed)
+	if o.ResignedOn != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Resigned:", o.ResignedOn)
+	}
+	if o.DateOfBirth != nil {
+		fmt.Fprintf(os.Stdout, "%-20s %s %d\n", "Date of Birth:", time.Month(o.DateOfBirth.Month), o.DateOfBirth.Year)
+	}
+	if o.Nationality != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Nationality:", o.Nationality)
+	}
+	if o.CountryOfResidence != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Resident In:", o.CountryOfResidence)
+	}
+	if o.Occupation != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Occupation:", o.Occupation)
+	}
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Service Address:", formatAddress(o.Address))
+	if o.PrincipalOfficeAddress != nil {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Principal Office:", formatAddress(*o.PrincipalOfficeAddress))
+	}
+	if o.ContactDetails != nil && o.ContactDetails.ContactName != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Contact:", o.ContactDetails.ContactName)
+	}
+	for _, n := range o.FormerNames {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Former Name:", strings.TrimSpace(n.Forenames+" "+n.Surname))
+	}
+	if id := o.Identification; id != nil {
+		fmt.Fprintf(os.Stdout, "\nIdentification\n")
+		for _, f := range []struct{ label, value string }{
+			{"Type:", id.IdentificationType},
+			{"Legal Form:", id.LegalForm},
+			{"Legal Authority:", id.LegalAuthority},
+			{"Place Registered:", id.PlaceRegistered},
+			{"Registration No:", id.RegistrationNumber},
+		} {
+			if f.value != "" {
+				fmt.Fprintf(os.Stdout, "  %-20s %s\n", f.label, f.value)
+			}
+		}
+	}
+	if o.Responsibilities != "" {
+		fmt.Fprintf(os.Stdout, "\nResponsibilities\n  %s\n", o.Responsibilities)
+	}
+	return nil
+}
+
+// OfficersAppointmentsCmd lists every appointment held by an officer.
+type OfficersAppointmentsCmd struct {
+	OfficerID    string `arg:"" optional:"" help:"Officer ID (omit to resolve from --name)"`
+	Company      string `help:"Company number to resolve --name against (uses default if omitted)"`
+	Name         string `help:"Officer name to look up at --company"`
+	ItemsPerPage int    `help:"Results per page" default:"50"`
+	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
+}
+
+func (c *OfficersAppointmentsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+
+	officerID := c.OfficerID
+	if officerID == "" {
+		if c.Name == "" {
+			return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("provide an officer ID, or --name with --company")}
+		}
+		cn, err := resolveCompanyNumber(c.Company)
+		if err != nil {
+			return err
+		}
+		if officerID, err = resolveOfficerID(ctx, client, cn, c.Name); err != nil {
+			return err
+		}
+	}
+
+	var result *chapi.OfficerAppointmentList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllOfficerAppointments(ctx, officerID, c.Limit)
+	} else {
+		result, err = client.GetOfficerAppointments(ctx, officerID, c.ItemsPerPage, c.StartIndex)
+	}
+	if err != nil {
+		return fmt.Errorf("get appointments: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "%s (%d appointments):\n\n", result.Name, result.TotalResults)
+	for _, a := range result.Items {
+		resigned := ""
+		if a.ResignedOn != "" {
+			resigned = fmt.Sprintf(" (resigned %s)", a.ResignedOn)
+		}
+		fmt.Fprintf(os.Stdout, "  %-10s  %-40s  %-12s  %-20s  appointed %s%s\n",
+			a.AppointedTo.CompanyNumber, a.AppointedTo.CompanyName, a.AppointedTo.CompanyStatus, a.OfficerRole, a.AppointedOn, resigned)
 	}
 	return nil
 }
+
+// resolveOfficerID finds the officer ID for the officer at companyNumber whose
+// name contains name (case-insensitive). It fails if no officer or more than
+// one distinct officer matches.
+func resolveOfficerID(ctx context.Context, client *chapi.Client, companyNumber, name string) (string, error) {
+	officers, err := client.ListAllOfficers(ctx, companyNumber, 0)
+	if err != nil {
+		return "", fmt.Errorf("list officers: %w", err)
+	}
+
+	needle := strings.ToLower(name)
+	var ids, names []string
+	for _, o := range officers.Items {
+		id := o.OfficerID()
+		if id == "" || !strings.Contains(strings.ToLower(o.Name), needle) || slices.Contains(ids, id) {
+			continue
+		}
+		ids = append(ids, id)
+		names = append(names, fmt.Sprintf("%s (%s)", o.Name, id))
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("no officer matching %q at company %s", name, companyNumber)
+	case 1:
+		return ids[0], nil
+	default:
+		return "", fmt.Errorf("%q matches several officers at company %s: %s", name, companyNumber, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,27 @@
+package cmd_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_OfficersListShowsAppointmentIDs(t *testing.T) {
+	useFake(t)
+
+	var err error
+	out := captureStdout(t, func() {
+		err = cmd.Execute([]string{"officers", "list", "00445790"})
+	})
+	if err != nil {
+		t.Fatalf("officers list error: %v", err)
+	}
+	const id = "kT3mP8vQ2xR5nL9wJ4hB01APPT"
+	if !strings.Contains(out, id) {
+		t.Errorf("officers list output lacks appointment ID %s:\n%s", id, out)
+	}
+	if err := cmd.Execute([]string{"officers", "get", "00445790", id}); err != nil {
+		t.Errorf("officers get %s error: %v", id, err)
+	}
+}
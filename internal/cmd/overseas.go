@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// OverseasCmd retrieves Register of Overseas Entities data.
+type OverseasCmd struct {
+	Get      OverseasGetCmd      `cmd:"" help:"Get an overseas entity's profile and ROE status"`
+	Owners   OverseasOwnersCmd   `cmd:"" help:"List registrable beneficial owners"`
+	Officers OverseasOfficersCmd `cmd:"" help:"List managing officers"`
+}
+
+// OverseasGetCmd retrieves an overseas entity profile.
+type OverseasGetCmd struct {
+	EntityNumber string `arg:"" help:"Overseas entity number (e.g. OE012345)"`
+}
+
+func (c *OverseasGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	entity, err := client.GetOverseasEntity(ctx, c.EntityNumber)
+	if err != nil {
+		return fmt.Errorf("get overseas entity: %w", err)
+	}
+	if u := ui.FromContext(ctx); u != nil && !entity.IsOverseasEntity() {
+		u.Warn(fmt.Sprintf("%s is not on the Register of Overseas Entities (type %s)", entity.CompanyNumber, entity.Type))
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, entity)
+	}
+
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Entity Name:", entity.CompanyName)
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Entity Number:", entity.CompanyNumber)
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "ROE Status:", entity.CompanyStatus)
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Type:", entity.Type)
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Registered:", entity.DateOfCreation)
+	if entity.DateOfCessation != "" {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Removed:", entity.DateOfCessation)
+	}
+	if d := entity.ForeignCompanyDetails; d != nil {
+		if r := d.OriginatingRegistry; r != nil {
+			fmt.Fprintf(os.Stdout, "%-20s %s (%s)\n", "Incorporated In:", r.Country, r.Name)
+		}
+		if d.RegistrationNumber != "" {
+			fmt.Fprintf(os.Stdout, "%-20s %s\n", "Registration No:", d.RegistrationNumber)
+		}
+		if d.LegalForm != "" {
+			fmt.Fprintf(os.Stdout, "%-20s %s\n", "Legal Form:", d.LegalForm)
+		}
+		if d.GovernedBy != "" {
+			fmt.Fprintf(os.Stdout, "%-20s %s\n", "Governed By:", d.GovernedBy)
+		}
+	}
+	fmt.Fprintf(os.Stdout, "%-20s %s\n", "Principal Office:", formatAddress(entity.RegisteredOffice))
+	if entity.ServiceAddress != nil {
+		fmt.Fprintf(os.Stdout, "%-20s %s\n", "Service Address:", formatAddress(*entity.ServiceAddress))
+	}
+	return nil
+}
+
+// OverseasOwnersCmd lists the registrable beneficial owners of an overseas
+// entity.
+type OverseasOwnersCmd struct {
+	EntityNumber string `arg:"" help:"Overseas entity number (e.g. OE012345)"`
+	ItemsPerPage int    `help:"Results per page" default:"25"`
+	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
+}
+
+func (c *OverseasOwnersCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	var result *chapi.BeneficialOwnerList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllBeneficialOwners(ctx, c.EntityNumber, c.Limit)
+	} else {
+		result, err = client.ListBeneficialOwners(ctx, c.EntityNumber, c.ItemsPerPage, c.StartIndex)
+	}
+	if err != nil {
+		return fmt.Errorf("list beneficial owners: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Registrable Beneficial Owners (%d active, %d ceased):\n\n", result.ActiveCount, result.CeasedCount)
+	for _, b := range result.Items {
+		name := b.Name
+		if name == "" {
+			name = "(details withheld)"
+		}
+		status := ""
+		if b.NotifiedOn != "" {
+			status = "notified " + b.NotifiedOn
+		}
+		if b.CeasedOn != "" {
+			status += fmt.Sprintf(" (ceased %s)", b.CeasedOn)
+		} else if b.Ceased {
+			status += " (ceased)"
+		}
+		category := b.Category()
+		if category == "" {
+			category = b.Kind
+		}
+		fmt.Fprintf(os.Stdout, "  %-12s  %-45s  %s\n", category, name, strings.TrimSpace(status))
+		if b.IsSanctioned {
+			fmt.Fprintln(os.Stdout, "    SANCTIONED")
+		}
+		if b.ViaTrust() {
+			fmt.Fprintln(os.Stdout, "    Held as trustee")
+		}
+		if len(b.NaturesOfControl) > 0 {
+			fmt.Fprintf(os.Stdout, "    Controls: %s\n", strings.Join(b.NaturesOfControl, "; "))
+		}
+	}
+	return nil
+}
+
+// OverseasOfficersCmd lists the managing officers of an overseas entity.
+type OverseasOfficersCmd struct {
+	EntityNumber string `arg:"" help:"Overseas entity number (e.g. OE012345)"`
+	ItemsPerPage int    `help:"Results per page" default:"35"`
+	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
+}
+
+func (c *OverseasOfficersCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	var result *chapi.ManagingOfficerList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllManagingOfficers(ctx, c.EntityNumber, c.Limit)
+	} else {
+		result, err = client.ListManagingOfficers(ctx, c.EntityNumber, c.ItemsPerPage, c.StartIndex)
+	}
+	if err != nil {
+		return fmt.Errorf("list managing officers: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Managing Officers (%d active, %d resigned):\n\n", result.ActiveCount, result.ResignedCount)
+	for _, o := range result.Items {
+		resigned := ""
+		if o.ResignedOn != "" {
+			resigned = fmt.Sprintf(" (resigned %s)", o.ResignedOn)
+		}
+		fmt.Fprintf(os.Stdout, "  %-45s  %-26s  appointed %s%s\n", o.Name, o.OfficerRole, o.AppointedOn, resigned)
+		if o.ContactDetails != nil && o.ContactDetails.ContactName != "" {
+			fmt.Fprintf(os.Stdout, "    Contact: %s\n", o.ContactDetails.ContactName)
+		}
+		if o.Responsibilities != "" {
+			fmt.Fprintf(os.Stdout, "    Responsibilities: %s\n", o.Responsibilities)
+		}
+	}
+	return nil
+}
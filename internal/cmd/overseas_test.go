@@ -0,0 +1,27 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_Overseas(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"overseas", "get", "OE012345"},
+		{"overseas", "get", "FC031234", "--json"},
+		{"overseas", "owners", "OE012345", "--all"},
+		{"overseas", "officers", "OE012345", "--json"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+
+	err := cmd.Execute([]string{"overseas", "get", "OE999999"})
+	if cmd.ExitCode(err) != cmd.ExitCodeNotFound {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeNotFound, err)
+	}
+}
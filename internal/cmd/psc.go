@@ -5,15 +5,18 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
 )
 
 // PSCCmd retrieves persons with significant control.
 type PSCCmd struct {
-	List PSCListCmd `cmd:"" help:"List persons with significant control"`
+	List       PSCListCmd       `cmd:"" help:"List persons with significant control"`
+	Get        PSCGetCmd        `cmd:"" help:"Get the full record for one PSC"`
+	Statements PSCStatementsCmd `cmd:"" help:"List PSC statements (e.g. no PSC, or PSC not yet identified)"`
+	Chain      PSCChainCmd      `cmd:"" help:"Trace ownership through corporate PSCs to the ultimate beneficial owners"`
 }
 
 // PSCListCmd lists PSCs for a company.
@@ -21,20 +24,25 @@ type PSCListCmd struct {
 	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
 	ItemsPerPage  int    `help:"Results per page" default:"25"`
 	StartIndex    int    `help:"Start index for pagination" default:"0"`
+	All           bool   `help:"Fetch every page of results"`
+	Limit         int    `help:"Stop after N results (implies --all)"`
 }
 
-func (c *PSCListCmd) Run(ctx context.Context) error {
+func (c *PSCListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	cn, err := resolveCompanyNumber(c.CompanyNumber)
 	if err != nil {
 		return err
 	}
-	apiKey, err := config.APIKey()
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
-	result, err := client.ListPSCs(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	var result *chapi.PSCList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllPSCs(ctx, cn, c.Limit)
+	} else {
+		result, err = client.ListPSCs(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	}
 	if err != nil {
 		return fmt.Errorf("list PSCs: %w", err)
 	}
@@ -57,3 +65,221 @@ func (c *PSCListCmd) Run(ctx context.Context) error {
 	}
 	return nil
 }
+
+// PSCGetCmd retrieves one PSC by ID.
+type PSCGetCmd struct {
+	CompanyNumber string `arg:"" help:"Company number"`
+	PSCID         string `arg:"" help:"PSC ID or self link from psc list --json"`
+	Kind          string `help:"PSC kind (looked up from the company's PSC list if omitted)" enum:",individual,corporate-entity,legal-person,super-secure,individual-beneficial-owner,corporate-entity-beneficial-owner,legal-person-beneficial-owner,super-secure-beneficial-owner" default:""`
+}
+
+func (c *PSCGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	kind, id := c.Kind, c.PSCID
+	if _, k, linkID, ok := chapi.ParsePSCLink(id); ok {
+		kind, id = k, linkID
+	}
+
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	if kind == "" {
+		if kind, err = resolvePSCKind(ctx, client, cn, id); err != nil {
+			return err
+		}
+	}
+
+	var (
+		result any
+		rows   []pscRow
+	)
+	switch kind {
+	case chapi.PSCKindIndividual:
+		p, err := client.GetIndividualPSC(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result, rows = p, individualPSCRows(p)
+	case chapi.PSCKindIndividualBeneficialOwner:
+		p, err := client.GetIndividualBeneficialOwner(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result = p
+		rows = append(individualPSCRows(&p.IndividualPSC), beneficialOwnerRows(p.IsSanctioned, nil)...)
+	case chapi.PSCKindCorporateEntity:
+		p, err := client.GetCorporateEntityPSC(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result, rows = p, entityPSCRows(p.Name, p.Kind, p.NotifiedOn, p.CeasedOn, p.Address, p.Identification, p.NaturesOfControl)
+	case chapi.PSCKindCorporateEntityBeneficialOwner:
+		p, err := client.GetCorporateEntityBeneficialOwner(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result = p
+		rows = append(entityPSCRows(p.Name, p.Kind, p.NotifiedOn, p.CeasedOn, p.Address, p.Identification, p.NaturesOfControl), beneficialOwnerRows(p.IsSanctioned, p.PrincipalOfficeAddress)...)
+	case chapi.PSCKindLegalPerson:
+		p, err := client.GetLegalPersonPSC(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result, rows = p, entityPSCRows(p.Name, p.Kind, p.NotifiedOn, p.CeasedOn, p.Address, p.Identification, p.NaturesOfControl)
+	case chapi.PSCKindLegalPersonBeneficialOwner:
+		p, err := client.GetLegalPersonBeneficialOwner(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result = p
+		rows = append(entityPSCRows(p.Name, p.Kind, p.NotifiedOn, p.CeasedOn, p.Address, p.Identification, p.NaturesOfControl), beneficialOwnerRows(p.IsSanctioned, p.PrincipalOfficeAddress)...)
+	case chapi.PSCKindSuperSecure, chapi.PSCKindSuperSecureBeneficialOwner:
+		get := client.GetSuperSecurePSC
+		if kind == chapi.PSCKindSuperSecureBeneficialOwner {
+			get = client.GetSuperSecureBeneficialOwner
+		}
+		p, err := get(ctx, cn, id)
+		if err != nil {
+			return fmt.Errorf("get PSC: %w", err)
+		}
+		result = p
+		rows = []pscRow{{"Kind:", p.Kind}, {"Description:", p.Description}, {"Ceased:", yesNo(p.Ceased)}}
+	default:
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("unknown PSC kind %q", kind)}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+	for _, r := range rows {
+		if r.value != "" {
+			fmt.Fprintf(os.Stdout, "%-20s %s\n", r.label, r.value)
+		}
+	}
+	return nil
+}
+
+// pscRow is one labelled line of PSC detail output. Rows with an empty value
+// are skipped.
+type pscRow struct{ label, value string }
+
+func individualPSCRows(p *chapi.IndividualPSC) []pscRow {
+	rows := []pscRow{
+		{"Name:", p.Name},
+		{"Kind:", p.Kind},
+		{"Notified:", p.NotifiedOn},
+		{"Ceased:", p.CeasedOn},
+	}
+	if p.DateOfBirth != nil {
+		rows = append(rows, pscRow{"Date of Birth:", fmt.Sprintf("%s %d", time.Month(p.DateOfBirth.Month), p.DateOfBirth.Year)})
+	}
+	return append(rows,
+		pscRow{"Nationality:", p.Nationality},
+		pscRow{"Resident In:", p.CountryOfResidence},
+		pscRow{"Address:", formatAddress(p.Address)},
+		pscRow{"Controls:", strings.Join(p.NaturesOfControl, "; ")},
+	)
+}
+
+// entityPSCRows formats a corporate entity or legal person PSC from the
+// fields the two kinds share. Legal persons usually give only a legal form
+// and authority; the empty registration rows are then skipped.
+func entityPSCRows(name, kind, notifiedOn, ceasedOn string, address chapi.RegisteredOffice, id *chapi.PSCIdentification, naturesOfControl []string) []pscRow {
+	rows := []pscRow{
+		{"Name:", name},
+		{"Kind:", kind},
+		{"Notified:", notifiedOn},
+		{"Ceased:", ceasedOn},
+		{"Address:", formatAddress(address)},
+	}
+	if id != nil {
+		rows = append(rows,
+			pscRow{"Legal Form:", id.LegalForm},
+			pscRow{"Legal Authority:", id.LegalAuthority},
+			pscRow{"Place Registered:", id.PlaceRegistered},
+			pscRow{"Registration No:", id.RegistrationNumber},
+			pscRow{"Country:", id.CountryRegistered},
+		)
+	}
+	return append(rows, pscRow{"Controls:", strings.Join(naturesOfControl, "; ")})
+}
+
+func beneficialOwnerRows(sanctioned bool, principal *chapi.RegisteredOffice) []pscRow {
+	rows := []pscRow{{"Sanctioned:", yesNo(sanctioned)}}
+	if principal != nil {
+		rows = append(rows, pscRow{"Principal Office:", formatAddress(*principal)})
+	}
+	return rows
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// resolvePSCKind finds the kind of the PSC with the given ID by scanning the
+// company's PSC list.
+func resolvePSCKind(ctx context.Context, client *chapi.Client, companyNumber, pscID string) (string, error) {
+	pscs, err := client.ListAllPSCs(ctx, companyNumber, 0)
+	if err != nil {
+		return "", fmt.Errorf("list PSCs: %w", err)
+	}
+	for _, p := range pscs.Items {
+		if _, kind, id, ok := chapi.ParsePSCLink(p.Links["self"]); ok && id == pscID {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("no PSC with ID %s at company %s: %w", pscID, companyNumber, chapi.ErrNotFound)
+}
+
+// PSCStatementsCmd lists PSC statements for a company.
+type PSCStatementsCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+	ItemsPerPage  int    `help:"Results per page" default:"25"`
+	StartIndex    int    `help:"Start index for pagination" default:"0"`
+	All           bool   `help:"Fetch every page of results"`
+	Limit         int    `help:"Stop after N results (implies --all)"`
+}
+
+func (c *PSCStatementsCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	var result *chapi.PSCStatementList
+	if c.All || c.Limit > 0 {
+		result, err = client.ListAllPSCStatements(ctx, cn, c.Limit)
+	} else {
+		result, err = client.ListPSCStatements(ctx, cn, c.ItemsPerPage, c.StartIndex)
+	}
+	if err != nil {
+		return fmt.Errorf("list PSC statements: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "PSC Statements (%d active, %d ceased):\n\n", result.ActiveCount, result.CeasedCount)
+	for _, st := range result.Items {
+		ceased := ""
+		if st.CeasedOn != "" {
+			ceased = fmt.Sprintf(" (ceased %s)", st.CeasedOn)
+		}
+		fmt.Fprintf(os.Stdout, "  %-60s  notified %s%s\n", st.Statement, st.NotifiedOn, ceased)
+		if st.LinkedPSCName != "" {
+			fmt.Fprintf(os.Stdout, "    Linked PSC: %s\n", st.LinkedPSCName)
+		}
+	}
+	return nil
+}
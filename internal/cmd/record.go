@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/anthonyencodeclub/ch/internal/vcr"
+)
+
+// configureTransport installs the transport selected by the hidden --record
+// and --replay flags. The returned function saves any recording and must be
+// called once the command has run.
+func configureTransport(flags *RootFlags) (func() error, error) {
+	noop := func() error { return nil }
+	switch {
+	case flags.Record != "" && flags.Replay != "":
+		return noop, &ExitError{Code: ExitCodeUsage, Err: errors.New("cannot combine --record and --replay")}
+	case flags.Record != "":
+		rec, err := vcr.NewRecorder(filepath.Join(flags.Record, vcr.CassetteFile), nil)
+		if err != nil {
+			return noop, err
+		}
+		flags.transport = rec
+		return rec.Save, nil
+	case flags.Replay != "":
+		rep, err := vcr.NewReplayer(filepath.Join(flags.Replay, vcr.CassetteFile))
+		if err != nil {
+			return noop, err
+		}
+		flags.transport = rep
+	}
+	return noop, nil
+}
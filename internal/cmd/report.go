@@ -0,0 +1,476 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// CompanyReportCmd builds a due-diligence dossier for a company from several
+// endpoints at once.
+type CompanyReportCmd struct {
+	CompanyNumber string `arg:"" optional:"" help:"Company number (uses default if omitted)"`
+	Format        string `help:"Report format: text|markdown|html|json" enum:"text,markdown,html,json" default:"text"`
+	Output        string `help:"Write the report to a file instead of stdout" short:"o" type:"path"`
+	Filings       int    `help:"Number of recent filings to include" default:"10"`
+	Concurrency   int    `help:"Endpoints to query at once" default:"4"`
+}
+
+// CompanyReport is everything the report knows about a company. Sections
+// that could not be fetched are nil and their error is recorded in Errors,
+// keyed by section name.
+type CompanyReport struct {
+	CompanyNumber    string                    `json:"company_number"`
+	GeneratedAt      time.Time                 `json:"generated_at"`
+	Profile          *chapi.CompanyProfile     `json:"profile,omitempty"`
+	RegisteredOffice *chapi.RegisteredOffice   `json:"registered_office,omitempty"`
+	Officers         *chapi.OfficerList        `json:"officers,omitempty"`
+	PSCs             *chapi.PSCList            `json:"pscs,omitempty"`
+	Charges          *chapi.ChargeList         `json:"charges,omitempty"`
+	Insolvency       *chapi.InsolvencyResponse `json:"insolvency,omitempty"`
+	Filings          *chapi.FilingHistoryList  `json:"filing_history,omitempty"`
+	Errors           map[string]string         `json:"errors,omitempty"`
+}
+
+// Report section names, used as keys in CompanyReport.Errors.
+const (
+	reportProfile          = "profile"
+	reportRegisteredOffice = "registered_office"
+	reportOfficers         = "officers"
+	reportPSCs             = "pscs"
+	reportCharges          = "charges"
+	reportInsolvency       = "insolvency"
+	reportFilings          = "filing_history"
+)
+
+func (c *CompanyReportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	cn, err := resolveCompanyNumber(c.CompanyNumber)
+	if err != nil {
+		return err
+	}
+	if c.Concurrency < 1 {
+		c.Concurrency = 1
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+
+	report, errs := buildCompanyReport(ctx, client, cn, c.Filings, c.Concurrency)
+	if err := errs[reportProfile]; errors.Is(err, chapi.ErrNotFound) {
+		return fmt.Errorf("get company: %w", err)
+	}
+	if len(errs) == len(reportSectionNames) {
+		return fmt.Errorf("build report: every section failed: %w", errs[reportProfile])
+	}
+	u := ui.FromContext(ctx)
+	if u != nil {
+		for _, name := range reportSectionNames {
+			if err := errs[name]; err != nil {
+				u.Warn(fmt.Sprintf("%s unavailable: %v", name, err))
+			}
+		}
+	}
+
+	format := c.Format
+	if outfmt.IsJSON(ctx) {
+		format = "json"
+	}
+	render := func(w io.Writer) error {
+		switch format {
+		case "json":
+			return outfmt.WriteJSON(w, report)
+		case "markdown":
+			return renderReportMarkdown(w, report)
+		case "html":
+			return renderReportHTML(w, report)
+		default:
+			return renderReportText(w, report)
+		}
+	}
+
+	if c.Output == "" {
+		return render(os.Stdout)
+	}
+	var buf bytes.Buffer
+	if err := render(&buf); err != nil {
+		return err
+	}
+	if _, err := writeFileAtomic(c.Output, buf.WriteTo); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	if u != nil {
+		u.Info("Wrote " + c.Output)
+	}
+	return nil
+}
+
+// reportSectionNames lists the report's sections in the order they are
+// rendered and warned about.
+var reportSectionNames = []string{
+	reportProfile,
+	reportRegisteredOffice,
+	reportOfficers,
+	reportPSCs,
+	reportCharges,
+	reportInsolvency,
+	reportFilings,
+}
+
+// buildCompanyReport fetches every section of a company's report, at most
+// concurrency at a time. A section that fails is left nil and its error is
+// returned keyed by section name; the rest of the report is still filled in.
+// Endpoints that answer 404 when a company has nothing to report (no PSCs,
+// charges or insolvency history) leave their section empty instead.
+func buildCompanyReport(ctx context.Context, client *chapi.Client, companyNumber string, filings, concurrency int) (*CompanyReport, map[string]error) {
+	r := &CompanyReport{
+		CompanyNumber: companyNumber,
+		GeneratedAt:   time.Now().UTC().Truncate(time.Second),
+	}
+	fetches := map[string]func() error{
+		reportProfile: func() (err error) {
+			r.Profile, err = client.GetCompany(ctx, companyNumber)
+			return err
+		},
+		reportRegisteredOffice: func() (err error) {
+			r.RegisteredOffice, err = client.GetRegisteredOffice(ctx, companyNumber)
+			return err
+		},
+		reportOfficers: func() (err error) {
+			r.Officers, err = client.ListAllOfficers(ctx, companyNumber, 0)
+			return err
+		},
+		reportPSCs: func() (err error) {
+			r.PSCs, err = client.ListAllPSCs(ctx, companyNumber, 0)
+			if errors.Is(err, chapi.ErrNotFound) {
+				r.PSCs, err = &chapi.PSCList{}, nil
+			}
+			return err
+		},
+		reportCharges: func() (err error) {
+			r.Charges, err = client.ListAllCharges(ctx, companyNumber, 0)
+			if errors.Is(err, chapi.ErrNotFound) {
+				r.Charges, err = &chapi.ChargeList{}, nil
+			}
+			return err
+		},
+		reportInsolvency: func() (err error) {
+			r.Insolvency, err = client.GetInsolvency(ctx, companyNumber)
+			if errors.Is(err, chapi.ErrNotFound) {
+				err = nil
+			}
+			return err
+		},
+		reportFilings: func() (err error) {
+			r.Filings, err = client.ListFilingHistory(ctx, companyNumber, "", filings, 0)
+			return err
+		},
+	}
+
+	results := make([]error, len(reportSectionNames))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, name := range reportSectionNames {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = fetches[name]()
+		}()
+	}
+	wg.Wait()
+
+	errs := map[string]error{}
+	for i, err := range results {
+		if err != nil {
+			name := reportSectionNames[i]
+			errs[name] = err
+			if r.Errors == nil {
+				r.Errors = map[string]string{}
+			}
+			r.Errors[name] = err.Error()
+		}
+	}
+	return r, errs
+}
+
+// reportSection is one rendered part of a report: label/value fields, a
+// table, or a note when there is nothing else to show.
+type reportSection struct {
+	Title   string
+	Fields  []reportField
+	Columns []string
+	Rows    [][]string
+	Note    string
+}
+
+type reportField struct{ Label, Value string }
+
+// title names the report after the company, falling back to its number
+// when the profile is unavailable.
+func (r *CompanyReport) title() string {
+	if r.Profile != nil && r.Profile.CompanyName != "" {
+		return fmt.Sprintf("%s (%s)", r.Profile.CompanyName, r.CompanyNumber)
+	}
+	return r.CompanyNumber
+}
+
+// sections lays the report out for the text, Markdown and HTML renderers.
+func (r *CompanyReport) sections() []reportSection {
+	unavailable := func(name string) string {
+		return "Unavailable: " + r.Errors[name]
+	}
+	var out []reportSection
+
+	company := reportSection{Title: "Company"}
+	if p := r.Profile; p != nil {
+		company.Fields = nonEmptyFields(
+			reportField{"Name:", p.CompanyName},
+			reportField{"Number:", p.CompanyNumber},
+			reportField{"Status:", p.CompanyStatus},
+			reportField{"Type:", p.Type},
+			reportField{"Incorporated:", p.DateOfCreation},
+			reportField{"Ceased:", p.DateOfCessation},
+			reportField{"Jurisdiction:", p.Jurisdiction},
+			reportField{"SIC Codes:", strings.Join(p.SICCodes, ", ")},
+		)
+		if a := p.Accounts; a != nil {
+			if a.LastAccounts != nil && a.LastAccounts.MadeUpTo != "" {
+				company.Fields = append(company.Fields, reportField{"Last Accounts:", strings.TrimSpace(a.LastAccounts.MadeUpTo + " " + a.LastAccounts.Type)})
+			}
+			if a.NextDue != "" {
+				company.Fields = append(company.Fields, reportField{"Accounts Due:", a.NextDue})
+			}
+		}
+		if cs := p.ConfirmationStatement; cs != nil && cs.NextDue != "" {
+			company.Fields = append(company.Fields, reportField{"Confirmation Due:", cs.NextDue})
+		}
+		for _, prev := range p.PreviousCompanyNames {
+			company.Fields = append(company.Fields, reportField{"Previous Name:", fmt.Sprintf("%s (until %s)", prev.Name, prev.CeasedOn)})
+		}
+	} else {
+		company.Note = unavailable(reportProfile)
+	}
+	out = append(out, company)
+
+	office := reportSection{Title: "Registered Office"}
+	if r.RegisteredOffice != nil {
+		office.Fields = nonEmptyFields(
+			reportField{"Address:", formatAddress(*r.RegisteredOffice)},
+			reportField{"Region:", r.RegisteredOffice.Region},
+			reportField{"Country:", r.RegisteredOffice.Country},
+		)
+	} else {
+		office.Note = unavailable(reportRegisteredOffice)
+	}
+	out = append(out, office)
+
+	officers := reportSection{Title: "Officers"}
+	if l := r.Officers; l != nil {
+		officers.Title = fmt.Sprintf("Officers (%d active, %d resigned)", l.ActiveCount, l.ResignedCount)
+		officers.Columns = []string{"Name", "Role", "Appointed", "Resigned"}
+		for _, o := range l.Items {
+			officers.Rows = append(officers.Rows, []string{o.Name, o.OfficerRole, o.AppointedOn, o.ResignedOn})
+		}
+	} else {
+		officers.Note = unavailable(reportOfficers)
+	}
+	out = append(out, officers)
+
+	pscs := reportSection{Title: "Persons with Significant Control"}
+	if l := r.PSCs; l != nil {
+		pscs.Title = fmt.Sprintf("Persons with Significant Control (%d active, %d ceased)", l.ActiveCount, l.CeasedCount)
+		pscs.Columns = []string{"Name", "Kind", "Notified", "Ceased", "Controls"}
+		for _, p := range l.Items {
+			name := p.Name
+			if name == "" {
+				name = p.Description
+			}
+			pscs.Rows = append(pscs.Rows, []string{name, p.Kind, p.NotifiedOn, p.CeasedOn, strings.Join(p.NaturesOfControl, "; ")})
+		}
+	} else {
+		pscs.Note = unavailable(reportPSCs)
+	}
+	out = append(out, pscs)
+
+	charges := reportSection{Title: "Charges"}
+	if l := r.Charges; l != nil {
+		charges.Title = fmt.Sprintf("Charges (%d total, %d satisfied)", l.TotalCount, l.SatisfiedCount)
+		charges.Columns = []string{"Code", "Status", "Created", "Persons Entitled", "Classification"}
+		for _, ch := range l.Items {
+			code := ch.ChargeCode
+			if code == "" && ch.ChargeNumber > 0 {
+				code = strconv.Itoa(ch.ChargeNumber)
+			}
+			created := ch.CreatedOn
+			if created == "" {
+				created = ch.DeliveredOn
+			}
+			var entitled []string
+			for _, p := range ch.PersonsEntitled {
+				entitled = append(entitled, p.Name)
+			}
+			charges.Rows = append(charges.Rows, []string{code, ch.Status, created, strings.Join(entitled, ", "), ch.Classification.Description})
+		}
+	} else {
+		charges.Note = unavailable(reportCharges)
+	}
+	out = append(out, charges)
+
+	insolvency := reportSection{Title: "Insolvency"}
+	switch {
+	case r.Errors[reportInsolvency] != "":
+		insolvency.Note = unavailable(reportInsolvency)
+	case r.Insolvency == nil:
+		insolvency.Note = "No insolvency history."
+	default:
+		insolvency.Title = fmt.Sprintf("Insolvency (%s)", r.Insolvency.Status)
+		insolvency.Columns = []string{"Case", "Type", "Dates", "Practitioners"}
+		for _, cs := range r.Insolvency.Cases {
+			var dates, practitioners []string
+			for _, d := range cs.Dates {
+				dates = append(dates, d.Type+" "+d.Date)
+			}
+			for _, p := range cs.Practitioners {
+				practitioners = append(practitioners, fmt.Sprintf("%s (%s)", p.Name, p.Role))
+			}
+			insolvency.Rows = append(insolvency.Rows, []string{strconv.Itoa(cs.Number), cs.Type, strings.Join(dates, "; "), strings.Join(practitioners, "; ")})
+		}
+	}
+	out = append(out, insolvency)
+
+	filings := reportSection{Title: "Recent Filings"}
+	if l := r.Filings; l != nil {
+		filings.Title = fmt.Sprintf("Recent Filings (%d of %d)", len(l.Items), l.TotalCount)
+		filings.Columns = []string{"Date", "Type", "Category", "Description"}
+		for _, f := range l.Items {
+			filings.Rows = append(filings.Rows, []string{f.Date, f.Type, f.Category, f.Description})
+		}
+	} else {
+		filings.Note = unavailable(reportFilings)
+	}
+	out = append(out, filings)
+
+	for i := range out {
+		if out[i].Note == "" && out[i].Fields == nil && len(out[i].Rows) == 0 {
+			out[i].Columns = nil
+			out[i].Note = "None recorded."
+		}
+	}
+	return out
+}
+
+func nonEmptyFields(fields ...reportField) []reportField {
+	var out []reportField
+	for _, f := range fields {
+		if f.Value != "" {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+func renderReportText(w io.Writer, r *CompanyReport) error {
+	fmt.Fprintf(w, "%s\nCompany report generated %s\n", r.title(), r.GeneratedAt.Format(time.RFC3339))
+	for _, s := range r.sections() {
+		fmt.Fprintf(w, "\n%s:\n\n", s.Title)
+		for _, f := range s.Fields {
+			fmt.Fprintf(w, "  %-20s %s\n", f.Label, f.Value)
+		}
+		if len(s.Rows) > 0 {
+			tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(tw, "  %s\n", strings.Join(s.Columns, "\t"))
+			for _, row := range s.Rows {
+				fmt.Fprintf(tw, "  %s\n", strings.Join(row, "\t"))
+			}
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+		}
+		if s.Note != "" {
+			fmt.Fprintf(w, "  %s\n", s.Note)
+		}
+	}
+	return nil
+}
+
+func renderReportMarkdown(w io.Writer, r *CompanyReport) error {
+	cell := strings.NewReplacer("|", `\|`, "\n", " ").Replace
+	fmt.Fprintf(w, "# %s\n\n_Company report generated %s_\n", cell(r.title()), r.GeneratedAt.Format(time.RFC3339))
+	for _, s := range r.sections() {
+		fmt.Fprintf(w, "\n## %s\n\n", s.Title)
+		for _, f := range s.Fields {
+			fmt.Fprintf(w, "- **%s** %s\n", f.Label, cell(f.Value))
+		}
+		if len(s.Rows) > 0 {
+			fmt.Fprintf(w, "| %s |\n|%s\n", strings.Join(s.Columns, " | "), strings.Repeat(" --- |", len(s.Columns)))
+			for _, row := range s.Rows {
+				cells := make([]string, len(row))
+				for i, v := range row {
+					cells[i] = cell(v)
+				}
+				fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+			}
+		}
+		if s.Note != "" {
+			fmt.Fprintf(w, "_%s_\n", s.Note)
+		}
+	}
+	return nil
+}
+
+var reportHTML = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: system-ui, sans-serif; margin: 2rem auto; max-width: 70rem; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1rem; }
+th, td { border: 1px solid #ccc; padding: 0.3rem 0.5rem; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+dl { display: grid; grid-template-columns: max-content auto; gap: 0.2rem 1rem; }
+dt { font-weight: bold; }
+dd { margin: 0; }
+.note { color: #666; font-style: italic; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="note">Company report generated {{.Generated}}</p>
+{{range .Sections}}
+<h2>{{.Title}}</h2>
+{{if .Fields}}<dl>
+{{range .Fields}}<dt>{{.Label}}</dt><dd>{{.Value}}</dd>
+{{end}}</dl>{{end}}
+{{if .Rows}}<table>
+<tr>{{range .Columns}}<th>{{.}}</th>{{end}}</tr>
+{{range .Rows}}<tr>{{range .}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>{{end}}
+{{with .Note}}<p class="note">{{.}}</p>{{end}}
+{{end}}
+</body>
+</html>
+`))
+
+func renderReportHTML(w io.Writer, r *CompanyReport) error {
+	return reportHTML.Execute(w, struct {
+		Title     string
+		Generated string
+		Sections  []reportSection
+	}{r.title(), r.GeneratedAt.Format(time.RFC3339), r.sections()})
+}
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
+	"time"
 
 	"github.com/alecthomas/kong"
 
@@ -25,6 +27,22 @@ type RootFlags struct {
 	JSON    bool   `help:"Output JSON to stdout (best for scripting)" default:"false"`
 	Plain   bool   `help:"Output stable, parseable text to stdout (no colors)" default:"false"`
 	Verbose bool   `help:"Enable verbose logging"`
+
+	BaseURL     string        `help:"Companies House API base URL" env:"CH_BASE_URL" default:"https://api.company-information.service.gov.uk"`
+	DocumentURL string        `help:"Companies House Document API base URL" env:"CH_DOCUMENT_URL" default:"https://document-api.company-information.service.gov.uk"`
+	StreamURL   string        `help:"Companies House Streaming API base URL" env:"CH_STREAM_URL" default:"https://stream.companieshouse.gov.uk"`
+	Timeout     time.Duration `help:"Timeout for each API request" default:"30s"`
+	Retries     int           `help:"Maximum attempts per API request" default:"3"`
+
+	CacheTTL time.Duration `help:"Override how long cached API responses stay fresh (e.g. 10m)"`
+	NoCache  bool          `help:"Bypass the response cache"`
+	Offline  bool          `help:"Serve API responses from the cache only (no network)"`
+
+	Record string `help:"Record API exchanges to a cassette in DIR" placeholder:"DIR" hidden:""`
+	Replay string `help:"Replay API exchanges from a cassette in DIR" placeholder:"DIR" hidden:""`
+
+	// transport is set from --record/--replay after parsing.
+	transport http.RoundTripper
 }
 
 // CLI is the top-level command tree.
@@ -33,17 +51,23 @@ type CLI struct {
 
 	Version    kong.VersionFlag `help:"Print version and exit"`
 
-	Auth       AuthCmd       `cmd:"" help:"Manage API key authentication"`
-	Setup      SetupCmd      `cmd:"" help:"Set up a new company (interactive guided flow)"`
-	Company    CompanyCmd    `cmd:"" help:"Company profile and registered office"`
-	Search     SearchCmd     `cmd:"" help:"Search companies, officers, and disqualified officers"`
-	Officers   OfficersCmd   `cmd:"" help:"List and view company officers"`
-	Filing     FilingCmd     `cmd:"" help:"Filing history"`
-	PSC        PSCCmd        `cmd:"" help:"Persons with significant control"`
-	Charges    ChargesCmd    `cmd:"" help:"Company charges (mortgages/securities)"`
-	Insolvency InsolvencyCmd `cmd:"" help:"Insolvency information"`
-	File       FileCmd       `cmd:"" help:"File changes (registered address, email) — requires OAuth2 login"`
-	VersionCmd VersionCmd    `cmd:"" name:"version" help:"Print version"`
+	Auth         AuthCmd         `cmd:"" help:"Manage API key authentication"`
+	Setup        SetupCmd        `cmd:"" help:"Set up a new company (interactive guided flow)"`
+	Company      CompanyCmd      `cmd:"" help:"Company profile and registered office"`
+	Search       SearchCmd       `cmd:"" help:"Search companies, officers, and disqualified officers"`
+	Officers     OfficersCmd     `cmd:"" help:"List and view company officers"`
+	Disqualified DisqualifiedCmd `cmd:"" help:"Disqualified directors register"`
+	Filing       FilingCmd       `cmd:"" help:"Filing history"`
+	Accounts     AccountsCmd     `cmd:"" help:"Financial figures from filed accounts"`
+	PSC          PSCCmd          `cmd:"" help:"Persons with significant control"`
+	Charges      ChargesCmd      `cmd:"" help:"Company charges (mortgages/securities)"`
+	Insolvency   InsolvencyCmd   `cmd:"" help:"Insolvency information"`
+	Overseas     OverseasCmd     `cmd:"" help:"Register of Overseas Entities: profiles, beneficial owners, managing officers"`
+	Stream       StreamCmd       `cmd:"" help:"Follow a Streaming API resource as NDJSON"`
+	File         FileCmd         `cmd:"" help:"File changes (registered address, email) — requires OAuth2 login"`
+	Cache        CacheCmd        `cmd:"" help:"Manage the local API response cache"`
+	Dev          DevCmd          `cmd:"" help:"Local development tools"`
+	VersionCmd   VersionCmd      `cmd:"" name:"version" help:"Print version"`
 }
 
 type exitPanic struct{ code int }
@@ -86,7 +110,15 @@ func Execute(args []string) (err error) {
 
 	mode, err := outfmt.FromFlags(cli.JSON, cli.Plain)
 	if err != nil {
-		return &ExitError{Code: 2, Err: err}
+		return &ExitError{Code: ExitCodeUsage, Err: err}
+	}
+	if cli.Offline && cli.NoCache {
+		return &ExitError{Code: ExitCodeUsage, Err: errors.New("cannot combine --offline and --no-cache")}
+	}
+
+	saveRecording, err := configureTransport(&cli.RootFlags)
+	if err != nil {
+		return err
 	}
 
 	ctx := context.Background()
@@ -111,6 +143,9 @@ func Execute(args []string) (err error) {
 	kctx.Bind(&cli.RootFlags)
 
 	err = kctx.Run()
+	if saveErr := saveRecording(); saveErr != nil && err == nil {
+		err = fmt.Errorf("save recording: %w", saveErr)
+	}
 	if err == nil {
 		return nil
 	}
@@ -129,7 +164,7 @@ func wrapParseError(err error) error {
 	}
 	var parseErr *kong.ParseError
 	if errors.As(err, &parseErr) {
-		return &ExitError{Code: 2, Err: parseErr}
+		return &ExitError{Code: ExitCodeUsage, Err: parseErr}
 	}
 	return err
 }
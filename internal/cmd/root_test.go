@@ -1,9 +1,15 @@
 package cmd_test
 
 import (
+	"io"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/anthonyencodeclub/ch/internal/chfake"
 	"github.com/anthonyencodeclub/ch/internal/cmd"
+	"github.com/anthonyencodeclub/ch/internal/vcr"
 )
 
 func TestExecute_Help(t *testing.T) {
@@ -39,6 +45,16 @@ func TestExecute_AuthStatusNoKey(t *testing.T) {
 	}
 }
 
+func TestExecute_MissingAPIKey(t *testing.T) {
+	t.Setenv("CH_API_KEY", "")
+	t.Setenv("CH_CONFIG_DIR", t.TempDir())
+
+	err := cmd.Execute([]string{"company", "get", "00445790", "--no-cache"})
+	if cmd.ExitCode(err) != cmd.ExitCodeAuth {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeAuth, err)
+	}
+}
+
 func TestExecute_AuthSetKey(t *testing.T) {
 	t.Setenv("CH_CONFIG_DIR", t.TempDir())
 
@@ -61,3 +77,79 @@ func TestExecute_ConflictingOutputFlags(t *testing.T) {
 		t.Fatal("Execute(--json --plain) should return error")
 	}
 }
+
+func TestExecute_ConflictingCacheFlags(t *testing.T) {
+	err := cmd.Execute([]string{"version", "--offline", "--no-cache"})
+	if err == nil {
+		t.Fatal("Execute(--offline --no-cache) should return error")
+	}
+}
+
+func TestExecute_CacheStats(t *testing.T) {
+	t.Setenv("CH_CONFIG_DIR", t.TempDir())
+
+	err := cmd.Execute([]string{"cache", "stats", "--json"})
+	if err != nil {
+		t.Fatalf("Execute(cache stats) error: %v", err)
+	}
+}
+
+func TestExecute_ReplayCassette(t *testing.T) {
+	t.Setenv("CH_API_KEY", "")
+	t.Setenv("CH_CONFIG_DIR", t.TempDir())
+
+	dir := t.TempDir()
+	c := &vcr.Cassette{Interactions: []vcr.Interaction{{
+		Request:  vcr.Request{Method: "GET", URL: "/company/00445790"},
+		Response: vcr.Response{StatusCode: 200, Body: `{"company_name": "TESCO PLC", "company_number": "00445790"}`},
+	}}}
+	if err := c.Save(filepath.Join(dir, vcr.CassetteFile)); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	err := cmd.Execute([]string{"company", "get", "00445790", "--replay", dir, "--json"})
+	if err != nil {
+		t.Fatalf("Execute(company get --replay) error: %v", err)
+	}
+}
+
+func TestExecute_RecordAndReplayConflict(t *testing.T) {
+	err := cmd.Execute([]string{"version", "--record", t.TempDir(), "--replay", t.TempDir()})
+	if err == nil {
+		t.Fatal("Execute(--record --replay) should return error")
+	}
+}
+
+// useFake points the CLI at a fake Companies House seeded with the default
+// fixtures.
+func useFake(t *testing.T) {
+	t.Helper()
+	srv := httptest.NewServer(chfake.New(chfake.DefaultDataset()))
+	t.Cleanup(srv.Close)
+	t.Setenv("CH_CONFIG_DIR", t.TempDir())
+	t.Setenv("CH_API_KEY", "test-key")
+	t.Setenv("CH_BASE_URL", srv.URL)
+	t.Setenv("CH_DOCUMENT_URL", srv.URL)
+	t.Setenv("CH_STREAM_URL", srv.URL+chfake.StreamPath)
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns what it wrote.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = orig }()
+
+	done := make(chan []byte)
+	go func() {
+		b, _ := io.ReadAll(r)
+		done <- b
+	}()
+	fn()
+	w.Close()
+	return string(<-done)
+}
@@ -4,16 +4,88 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/anthonyencodeclub/ch/internal/chapi"
-	"github.com/anthonyencodeclub/ch/internal/config"
 	"github.com/anthonyencodeclub/ch/internal/outfmt"
+	"github.com/anthonyencodeclub/ch/internal/ui"
 )
 
-// SearchCmd searches Companies House data.
+// SearchCmd searches Companies House data. Without a subcommand it searches
+// companies, officers and disqualified officers together.
 type SearchCmd struct {
-	Companies SearchCompaniesCmd `cmd:"" help:"Search for companies"`
-	Officers  SearchOfficersCmd  `cmd:"" help:"Search for officers"`
+	All          SearchAllCmd          `cmd:"" default:"withargs" help:"Search companies, officers and disqualified officers together (default)"`
+	Companies    SearchCompaniesCmd    `cmd:"" help:"Search for companies"`
+	Officers     SearchOfficersCmd     `cmd:"" help:"Search for officers"`
+	Disqualified SearchDisqualifiedCmd `cmd:"" help:"Search for disqualified officers"`
+	Advanced     SearchAdvancedCmd     `cmd:"" help:"Search for companies by status, type, SIC code, location and dates"`
+	Alphabetical SearchAlphabeticalCmd `cmd:"" help:"Browse the companies either side of a name on the register"`
+	Dissolved    SearchDissolvedCmd    `cmd:"" help:"Search dissolved companies, including by previous name"`
+}
+
+// SearchAllCmd runs a single ranked search across every kind of result.
+type SearchAllCmd struct {
+	Query        string   `arg:"" help:"Search query"`
+	Kind         []string `help:"Only show results of this kind: company|officer|disqualified-officer (repeatable)" enum:"company,officer,disqualified-officer" sep:","`
+	ItemsPerPage int      `help:"Results per page" default:"20"`
+	StartIndex   int      `help:"Start index for pagination" default:"0"`
+	All          bool     `help:"Fetch every page of results"`
+	Limit        int      `help:"Stop after N results (implies --all)"`
+}
+
+func (c *SearchAllCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	keep := func(item chapi.SearchItem) bool {
+		return len(c.Kind) == 0 || slices.Contains(c.Kind, item.ResultKind())
+	}
+
+	var result *chapi.SearchAllResult
+	if c.All || c.Limit > 0 {
+		// Filter while paging so --limit counts only the kinds asked for.
+		result = &chapi.SearchAllResult{Items: []chapi.SearchItem{}}
+		for item, err := range client.SearchAllPages(ctx, c.Query, 0) {
+			if err != nil {
+				return fmt.Errorf("search: %w", err)
+			}
+			if !keep(item) {
+				continue
+			}
+			result.Items = append(result.Items, item)
+			if c.Limit > 0 && len(result.Items) >= c.Limit {
+				break
+			}
+		}
+		result.TotalResults = len(result.Items)
+		result.ItemsPerPage = len(result.Items)
+	} else {
+		result, err = client.SearchAll(ctx, c.Query, c.ItemsPerPage, c.StartIndex)
+		if err != nil {
+			return fmt.Errorf("search: %w", err)
+		}
+		result.Items = slices.DeleteFunc(result.Items, func(item chapi.SearchItem) bool { return !keep(item) })
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	if len(c.Kind) > 0 && !c.All && c.Limit == 0 {
+		fmt.Fprintf(os.Stdout, "Found %d results, %d on this page of kind %s:\n\n", result.TotalResults, len(result.Items), strings.Join(c.Kind, ", "))
+	} else {
+		fmt.Fprintf(os.Stdout, "Found %d results:\n\n", result.TotalResults)
+	}
+	for _, item := range result.Items {
+		fmt.Fprintf(os.Stdout, "  %-20s  %-45s  %s\n", item.ResultKind(), item.Title, strings.TrimPrefix(item.Description, " - "))
+		if item.AddressSnippet != "" {
+			fmt.Fprintf(os.Stdout, "  %-20s  %s\n", "", item.AddressSnippet)
+		}
+	}
+	return nil
 }
 
 // SearchCompaniesCmd searches for companies.
@@ -21,16 +93,21 @@ type SearchCompaniesCmd struct {
 	Query        string `arg:"" help:"Search query"`
 	ItemsPerPage int    `help:"Results per page" default:"20"`
 	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
 }
 
-func (c *SearchCompaniesCmd) Run(ctx context.Context) error {
-	apiKey, err := config.APIKey()
+func (c *SearchCompaniesCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
-	result, err := client.SearchCompanies(ctx, c.Query, c.ItemsPerPage, c.StartIndex)
+	var result *chapi.SearchResult
+	if c.All || c.Limit > 0 {
+		result, err = client.SearchAllCompanies(ctx, c.Query, c.Limit)
+	} else {
+		result, err = client.SearchCompanies(ctx, c.Query, c.ItemsPerPage, c.StartIndex)
+	}
 	if err != nil {
 		return fmt.Errorf("search companies: %w", err)
 	}
@@ -55,16 +132,21 @@ type SearchOfficersCmd struct {
 	Query        string `arg:"" help:"Search query"`
 	ItemsPerPage int    `help:"Results per page" default:"20"`
 	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
 }
 
-func (c *SearchOfficersCmd) Run(ctx context.Context) error {
-	apiKey, err := config.APIKey()
+func (c *SearchOfficersCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
 	if err != nil {
 		return err
 	}
-
-	client := chapi.New(apiKey)
-	result, err := client.SearchOfficers(ctx, c.Query, c.ItemsPerPage, c.StartIndex)
+	var result *chapi.OfficerSearchResult
+	if c.All || c.Limit > 0 {
+		result, err = client.SearchAllOfficers(ctx, c.Query, c.Limit)
+	} else {
+		result, err = client.SearchOfficers(ctx, c.Query, c.ItemsPerPage, c.StartIndex)
+	}
 	if err != nil {
 		return fmt.Errorf("search officers: %w", err)
 	}
@@ -83,3 +165,213 @@ func (c *SearchOfficersCmd) Run(ctx context.Context) error {
 	}
 	return nil
 }
+
+// SearchDisqualifiedCmd searches the disqualified officers register.
+type SearchDisqualifiedCmd struct {
+	Query        string `arg:"" help:"Search query"`
+	ItemsPerPage int    `help:"Results per page" default:"20"`
+	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
+}
+
+func (c *SearchDisqualifiedCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	var result *chapi.DisqualifiedOfficerSearchResult
+	if c.All || c.Limit > 0 {
+		result, err = client.SearchAllDisqualifiedOfficers(ctx, c.Query, c.Limit)
+	} else {
+		result, err = client.SearchDisqualifiedOfficers(ctx, c.Query, c.ItemsPerPage, c.StartIndex)
+	}
+	if err != nil {
+		return fmt.Errorf("search disqualified officers: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Found %d results:\n\n", result.TotalResults)
+	for _, item := range result.Items {
+		kind, id, _ := item.OfficerKind()
+		fmt.Fprintf(os.Stdout, "  %-30s  %-9s  %-40s  %s\n", id, kind, item.Title, item.Description)
+	}
+	return nil
+}
+
+// SearchAdvancedCmd runs an advanced company search, fetching every page of
+// matches unless --limit is given.
+type SearchAdvancedCmd struct {
+	NameIncludes     string   `help:"Company name contains this text" name:"name-includes"`
+	NameExcludes     string   `help:"Company name does not contain this text" name:"name-excludes"`
+	Status           []string `help:"Company status, e.g. active (repeatable)" sep:","`
+	Subtype          []string `help:"Company subtype, e.g. community-interest-company (repeatable)" aliases:"sub-status" sep:","`
+	Type             []string `help:"Company type, e.g. ltd, plc, llp (repeatable)" sep:","`
+	SIC              []string `help:"SIC code (repeatable)" name:"sic" sep:","`
+	Location         string   `help:"Registered office address contains this text"`
+	IncorporatedFrom string   `help:"Incorporated on or after this date (YYYY-MM-DD)"`
+	IncorporatedTo   string   `help:"Incorporated on or before this date (YYYY-MM-DD)"`
+	DissolvedFrom    string   `help:"Dissolved on or after this date (YYYY-MM-DD)"`
+	DissolvedTo      string   `help:"Dissolved on or before this date (YYYY-MM-DD)"`
+	Limit            int      `help:"Stop after N results (default: every match)"`
+}
+
+func (c *SearchAdvancedCmd) Run(ctx context.Context, flags *RootFlags) error {
+	params := chapi.AdvancedSearchParams{
+		NameIncludes:     c.NameIncludes,
+		NameExcludes:     c.NameExcludes,
+		Status:           c.Status,
+		Subtype:          c.Subtype,
+		Type:             c.Type,
+		SICCodes:         c.SIC,
+		Location:         c.Location,
+		IncorporatedFrom: c.IncorporatedFrom,
+		IncorporatedTo:   c.IncorporatedTo,
+		DissolvedFrom:    c.DissolvedFrom,
+		DissolvedTo:      c.DissolvedTo,
+	}
+	for _, d := range []struct{ flag, value string }{
+		{"--incorporated-from", c.IncorporatedFrom},
+		{"--incorporated-to", c.IncorporatedTo},
+		{"--dissolved-from", c.DissolvedFrom},
+		{"--dissolved-to", c.DissolvedTo},
+	} {
+		if d.value == "" {
+			continue
+		}
+		if _, err := time.Parse(time.DateOnly, d.value); err != nil {
+			return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("%s must be YYYY-MM-DD: %w", d.flag, err)}
+		}
+	}
+	if len(params.Values()) == 0 {
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("at least one search filter is required")}
+	}
+
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	result, err := client.AdvancedSearchAllCompanies(ctx, params, c.Limit)
+	if err != nil {
+		return fmt.Errorf("advanced search: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	if len(result.Items) < result.Hits {
+		fmt.Fprintf(os.Stdout, "Found %d results (showing %d):\n\n", result.Hits, len(result.Items))
+	} else {
+		fmt.Fprintf(os.Stdout, "Found %d results:\n\n", result.Hits)
+	}
+	for _, item := range result.Items {
+		status := item.CompanyStatus
+		if status == "" {
+			status = "unknown"
+		}
+		fmt.Fprintf(os.Stdout, "  %-10s  %-50s  %-11s  %-5s  %-10s  %s\n",
+			item.CompanyNumber, item.CompanyName, status, item.CompanyType, item.DateOfCreation, item.RegisteredOffice.Locality)
+	}
+	return nil
+}
+
+// SearchAlphabeticalCmd browses the register in name order around a query.
+type SearchAlphabeticalCmd struct {
+	Query string `arg:"" help:"Company name to start from"`
+	Size  int    `help:"Companies to list" default:"20"`
+	Above string `help:"List the companies before this ordered_alpha_key_with_id" xor:"page"`
+	Below string `help:"List the companies after this ordered_alpha_key_with_id" xor:"page"`
+}
+
+func (c *SearchAlphabeticalCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	result, err := client.AlphabeticalSearchCompanies(ctx, c.Query, c.Above, c.Below, c.Size)
+	if err != nil {
+		return fmt.Errorf("alphabetical search: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	if len(result.Items) == 0 {
+		fmt.Fprintln(os.Stdout, "No companies found")
+		return nil
+	}
+	for _, item := range result.Items {
+		marker := " "
+		if result.TopHit != nil && item.CompanyNumber == result.TopHit.CompanyNumber {
+			marker = "*"
+		}
+		fmt.Fprintf(os.Stdout, "%s %-10s  %-50s  %s\n", marker, item.CompanyNumber, item.CompanyName, item.CompanyStatus)
+	}
+	if u := ui.FromContext(ctx); u != nil {
+		first, last := result.Items[0], result.Items[len(result.Items)-1]
+		u.Info(fmt.Sprintf("Earlier: --above %s  Later: --below %s", first.OrderedAlphaKeyWithID, last.OrderedAlphaKeyWithID))
+	}
+	return nil
+}
+
+// SearchDissolvedCmd searches dissolved companies.
+type SearchDissolvedCmd struct {
+	Query        string `arg:"" help:"Search query"`
+	Type         string `help:"Search type: alphabetical|best-match|previous-name-dissolved" enum:"alphabetical,best-match,previous-name-dissolved" default:"best-match"`
+	ItemsPerPage int    `help:"Results per page" default:"20"`
+	StartIndex   int    `help:"Start index for pagination" default:"0"`
+	Above        string `help:"Alphabetical only: list the companies before this ordered_alpha_key_with_id" xor:"page"`
+	Below        string `help:"Alphabetical only: list the companies after this ordered_alpha_key_with_id" xor:"page"`
+	All          bool   `help:"Fetch every page of results"`
+	Limit        int    `help:"Stop after N results (implies --all)"`
+}
+
+func (c *SearchDissolvedCmd) Run(ctx context.Context, flags *RootFlags) error {
+	alphabetical := c.Type == chapi.DissolvedSearchAlphabetical
+	switch {
+	case (c.Above != "" || c.Below != "") && !alphabetical:
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("--above and --below need --type alphabetical")}
+	case (c.Above != "" || c.Below != "") && (c.All || c.Limit > 0):
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("--above and --below cannot be combined with --all or --limit")}
+	case c.StartIndex > 0 && alphabetical:
+		return &ExitError{Code: ExitCodeUsage, Err: fmt.Errorf("alphabetical searches page with --above and --below, not --start-index")}
+	}
+	client, err := newClient(flags)
+	if err != nil {
+		return err
+	}
+	var result *chapi.DissolvedSearchResult
+	switch {
+	case c.All || c.Limit > 0:
+		result, err = client.DissolvedSearchAllCompanies(ctx, c.Query, c.Type, c.Limit)
+	case alphabetical:
+		result, err = client.DissolvedAlphabeticalSearchCompanies(ctx, c.Query, c.Above, c.Below, c.ItemsPerPage)
+	default:
+		result, err = client.DissolvedSearchCompanies(ctx, c.Query, c.Type, c.ItemsPerPage, c.StartIndex)
+	}
+	if err != nil {
+		return fmt.Errorf("search dissolved companies: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(os.Stdout, result)
+	}
+
+	fmt.Fprintf(os.Stdout, "Found %d results:\n\n", result.Hits)
+	for _, item := range result.Items {
+		fmt.Fprintf(os.Stdout, "  %-10s  %-50s  %s to %s\n", item.CompanyNumber, item.CompanyName, item.DateOfCreation, item.DateOfCessation)
+		if m := item.MatchedPreviousCompanyName; m != nil {
+			fmt.Fprintf(os.Stdout, "  %-10s  previously %s (%s to %s)\n", "", m.Name, m.EffectiveFrom, m.CeasedOn)
+		}
+	}
+	if u := ui.FromContext(ctx); u != nil && alphabetical && !c.All && c.Limit == 0 && len(result.Items) > 0 {
+		first, last := result.Items[0], result.Items[len(result.Items)-1]
+		u.Info(fmt.Sprintf("Earlier: --above %s  Later: --below %s", first.OrderedAlphaKeyWithID, last.OrderedAlphaKeyWithID))
+	}
+	return nil
+}
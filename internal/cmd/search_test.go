@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_SearchAdvanced(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"search", "advanced", "--status", "active", "--type", "ltd", "--sic", "62020", "--sic", "64209", "--location", "Leeds", "--incorporated-from", "2020-01-01"},
+		{"search", "advanced", "--name-includes", "northwind", "--limit", "1", "--json"},
+		{"search", "advanced", "--dissolved-from", "2000-01-01"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+}
+
+func TestExecute_SearchAdvancedUsage(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"search", "advanced"},
+		{"search", "advanced", "--incorporated-from", "2020"},
+	} {
+		err := cmd.Execute(args)
+		if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+			t.Errorf("Execute(%v) ExitCode = %d, want %d (err: %v)", args, cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+		}
+	}
+}
+
+func TestExecute_SearchAlphabeticalAndDissolved(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"search", "alphabetical", "northwind", "--size", "3"},
+		{"search", "alphabetical", "tesco", "--below", "NORTHWINDHOLDINGSLIMITED:13876501", "--json"},
+		{"search", "dissolved", "old mill trading", "--type", "previous-name-dissolved"},
+		{"search", "dissolved", "old mill", "--all", "--json"},
+		{"search", "dissolved", "old", "--type", "alphabetical", "--limit", "5"},
+		{"search", "dissolved", "old", "--type", "alphabetical", "--below", "OLDMILLBAKERYLIMITED:06123789"},
+		{"search", "dissolved", "zzz unmatched"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"search", "alphabetical", "tesco", "--above", "A:1", "--below", "B:2"},
+		{"search", "dissolved", "old mill", "--above", "A:1"},
+		{"search", "dissolved", "old mill", "--type", "alphabetical", "--below", "A:1", "--all"},
+		{"search", "dissolved", "old mill", "--type", "alphabetical", "--start-index", "20"},
+	} {
+		err := cmd.Execute(args)
+		if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+			t.Errorf("Execute(%v) ExitCode = %d, want %d (err: %v)", args, cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+		}
+	}
+}
+
+func TestExecute_SearchDefault(t *testing.T) {
+	useFake(t)
+
+	for _, args := range [][]string{
+		{"search", "mill"},
+		{"search", "mill", "--kind", "company", "--json"},
+		{"search", "all", "m", "--kind", "officer,disqualified-officer", "--limit", "2"},
+	} {
+		if err := cmd.Execute(args); err != nil {
+			t.Errorf("Execute(%v) error: %v", args, err)
+		}
+	}
+
+	err := cmd.Execute([]string{"search", "mill", "--kind", "person"})
+	if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+		t.Errorf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+	}
+}
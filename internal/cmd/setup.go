@@ -16,7 +16,7 @@ import (
 // SetupCmd is the guided company setup flow.
 type SetupCmd struct{}
 
-func (c *SetupCmd) Run(ctx context.Context) error {
+func (c *SetupCmd) Run(ctx context.Context, flags *RootFlags) error {
 	u := ui.FromContext(ctx)
 	reader := bufio.NewReader(os.Stdin)
 
@@ -67,7 +67,7 @@ func (c *SetupCmd) Run(ctx context.Context) error {
 		return fmt.Errorf("company name or number is required")
 	}
 
-	client := chapi.New(apiKey)
+	client := chapi.New(apiKey, clientOptions(flags)...)
 
 	// Try as a company number first (8 digits, possibly with leading zeros)
 	companyNumber := query
@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/config"
+	"github.com/anthonyencodeclub/ch/internal/sink"
+	"github.com/anthonyencodeclub/ch/internal/ui"
+)
+
+// The checkpoint is saved after this many events or this long since the
+// last save, whichever comes first, and always when the stream stops.
+const (
+	checkpointEvery    = 100
+	checkpointInterval = 5 * time.Second
+)
+
+// StreamCmd follows a Streaming API resource and delivers matching events to
+// one or more sinks (NDJSON on stdout by default). The next timepoint is
+// saved periodically once every sink has accepted an event, and again on
+// shutdown, so a restarted stream carries on where the last one stopped.
+type StreamCmd struct {
+	Resource   string `arg:"" enum:"companies,filings,officers,persons-with-significant-control,charges,insolvency-cases,disqualified-officers,company-exemptions,persons-with-significant-control-statements" help:"Stream to follow: companies, filings, officers, persons-with-significant-control, charges, insolvency-cases, disqualified-officers, company-exemptions or persons-with-significant-control-statements"`
+	Timepoint  int64  `help:"Start from this timepoint (default: resume from the checkpoint, or stream from now)"`
+	Limit      int    `help:"Stop after N delivered events"`
+	Key        string `help:"Streaming API key (defaults to the API key)" env:"CH_STREAM_KEY"`
+	Checkpoint string `help:"File recording the next timepoint (default: stream/<resource>.timepoint in the config dir)" type:"path"`
+
+	Sink           []string      `help:"Where to deliver events: stdout, file:DIR, webhook:URL or sqlite:PATH (repeatable)" placeholder:"SINK"`
+	RotateMB       int64         `name:"rotate-mb" help:"Start a new file sink file after this many megabytes" default:"100"`
+	RotateInterval time.Duration `help:"Start a new file sink file after this long (e.g. 24h)"`
+	WebhookRetries int           `help:"Attempts per event for webhook sinks" default:"5"`
+	Table          string        `help:"Table for sqlite sinks" default:"events"`
+
+	Company     []string `help:"Only deliver events for these company numbers (comma-separated or repeated)" sep:","`
+	CompanyFile string   `help:"Only deliver events for company numbers listed in FILE, one per line" type:"existingfile" placeholder:"FILE"`
+	Type        []string `help:"Only deliver these event types: changed, deleted" sep:","`
+	Kind        []string `help:"Only deliver these resource kinds (e.g. company-profile, company-psc-individual)" sep:","`
+}
+
+func (c *StreamCmd) Run(ctx context.Context, flags *RootFlags) error {
+	client, err := newStreamClient(flags, c.Key)
+	if err != nil {
+		return err
+	}
+	checkpoint := c.Checkpoint
+	if checkpoint == "" {
+		dir, err := config.StreamDir()
+		if err != nil {
+			return err
+		}
+		checkpoint = filepath.Join(dir, c.Resource+".timepoint")
+	}
+
+	start := c.Timepoint
+	if start == 0 {
+		if start, err = readCheckpoint(checkpoint); err != nil {
+			return err
+		}
+	}
+
+	u := ui.FromContext(ctx)
+	if u != nil && start > 0 {
+		u.Info(fmt.Sprintf("Streaming %s from timepoint %d", c.Resource, start))
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	filter, err := c.filter()
+	if err != nil {
+		return err
+	}
+	out, err := c.openSinks()
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	var (
+		next, saved int64
+		pending     int
+		savedAt     = time.Now()
+	)
+	save := func() error {
+		if next == saved {
+			return nil
+		}
+		if err := writeCheckpoint(checkpoint, next); err != nil {
+			return err
+		}
+		saved, pending, savedAt = next, 0, time.Now()
+		return nil
+	}
+	opts := chapi.StreamOptions{
+		Timepoint: start,
+		OnReconnect: func(err error, wait time.Duration) {
+			if u != nil {
+				u.Warn(fmt.Sprintf("Stream interrupted (%v); reconnecting in %s", err, wait))
+			}
+		},
+	}
+	err = client.Stream(ctx, c.Resource, opts, func(e chapi.StreamEvent) error {
+		if filter.Match(e) {
+			if err := out.Write(ctx, e); err != nil {
+				return err
+			}
+			count++
+		}
+		next = e.Event.Timepoint + 1
+		if pending++; pending >= checkpointEvery || time.Since(savedAt) >= checkpointInterval {
+			if err := save(); err != nil {
+				return err
+			}
+		}
+		if c.Limit > 0 && count >= c.Limit {
+			return chapi.ErrStopStream
+		}
+		return nil
+	})
+	if cerr := out.Close(); err == nil && cerr != nil {
+		err = cerr
+	}
+	if serr := save(); err == nil && serr != nil {
+		err = serr
+	}
+
+	var apiErr *chapi.APIError
+	switch {
+	case err == nil, errors.Is(err, context.Canceled):
+		return nil
+	case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		hint := "pass a newer --timepoint"
+		if c.Timepoint == 0 {
+			hint = "remove " + checkpoint + " to stream from now"
+		}
+		return fmt.Errorf("timepoint %d is out of range for the %s stream (%s): %w", start, c.Resource, hint, err)
+	}
+	return fmt.Errorf("stream %s: %w", c.Resource, err)
+}
+
+// openSinks opens every --sink, defaulting to NDJSON on stdout.
+func (c *StreamCmd) openSinks() (sink.Multi, error) {
+	specs := c.Sink
+	if len(specs) == 0 {
+		specs = []string{"stdout"}
+	}
+	opts := sink.Options{
+		Stream:         c.Resource,
+		RotateBytes:    c.RotateMB << 20,
+		RotateInterval: c.RotateInterval,
+		WebhookRetries: c.WebhookRetries,
+		UserAgent:      "ch/" + strings.TrimSpace(version),
+		Table:          c.Table,
+		Stdout:         os.Stdout,
+	}
+	var out sink.Multi
+	for _, spec := range specs {
+		s, err := sink.Open(spec, opts)
+		if err != nil {
+			out.Close()
+			return nil, &ExitError{Code: ExitCodeUsage, Err: err}
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// filter builds the event filter from the filtering flags.
+func (c *StreamCmd) filter() (sink.Filter, error) {
+	f := sink.Filter{Companies: c.Company, Types: c.Type, Kinds: c.Kind}
+	if c.CompanyFile != "" {
+		b, err := os.ReadFile(c.CompanyFile)
+		if err != nil {
+			return f, fmt.Errorf("read company file: %w", err)
+		}
+		for _, line := range strings.Split(string(b), "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+				f.Companies = append(f.Companies, line)
+			}
+		}
+	}
+	for i, n := range f.Companies {
+		f.Companies[i] = strings.ToUpper(strings.TrimSpace(n))
+	}
+	return f, nil
+}
+
+// readCheckpoint returns the timepoint saved in path, or 0 if there is none.
+func readCheckpoint(path string) (int64, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("read checkpoint: %w", err)
+	}
+	tp, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("read checkpoint %s: %w", path, err)
+	}
+	return tp, nil
+}
+
+// writeCheckpoint saves the next timepoint to path.
+func writeCheckpoint(path string, tp int64) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	if _, err := writeFileAtomic(path, func(w io.Writer) (int64, error) {
+		n, err := fmt.Fprintf(w, "%d\n", tp)
+		return int64(n), err
+	}); err != nil {
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return nil
+}
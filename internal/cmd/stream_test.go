@@ -0,0 +1,117 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/cmd"
+)
+
+func TestExecute_StreamCheckpoint(t *testing.T) {
+	useFake(t)
+	checkpoint := filepath.Join(t.TempDir(), "filings.timepoint")
+
+	read := func() string {
+		t.Helper()
+		b, err := os.ReadFile(checkpoint)
+		if err != nil {
+			t.Fatalf("read checkpoint: %v", err)
+		}
+		return strings.TrimSpace(string(b))
+	}
+
+	args := []string{"stream", "filings", "--limit", "2", "--checkpoint", checkpoint}
+	if err := cmd.Execute(args); err != nil {
+		t.Fatalf("Execute(stream) error: %v", err)
+	}
+	if got := read(); got != "3" {
+		t.Errorf("checkpoint = %q, want 3", got)
+	}
+
+	// A second run resumes from the checkpoint.
+	if err := cmd.Execute(args); err != nil {
+		t.Fatalf("second Execute(stream) error: %v", err)
+	}
+	if got := read(); got != "5" {
+		t.Errorf("checkpoint = %q, want 5", got)
+	}
+
+	// --timepoint overrides the checkpoint.
+	if err := cmd.Execute(append(args, "--timepoint", "1")); err != nil {
+		t.Fatalf("Execute(stream --timepoint) error: %v", err)
+	}
+	if got := read(); got != "3" {
+		t.Errorf("checkpoint = %q, want 3", got)
+	}
+}
+
+func TestExecute_StreamTimepointOutOfRange(t *testing.T) {
+	useFake(t)
+
+	err := cmd.Execute([]string{"stream", "companies", "--timepoint", "999", "--checkpoint", filepath.Join(t.TempDir(), "tp")})
+	if err == nil || !strings.Contains(err.Error(), "out of range") {
+		t.Fatalf("Execute(stream) error = %v, want out of range", err)
+	}
+}
+
+func TestExecute_StreamSinksAndFilters(t *testing.T) {
+	useFake(t)
+	dir := t.TempDir()
+
+	var posted []chapi.StreamEvent
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e chapi.StreamEvent
+		if err := json.NewDecoder(r.Body).Decode(&e); err != nil {
+			t.Errorf("decode webhook body: %v", err)
+		}
+		posted = append(posted, e)
+	}))
+	defer hook.Close()
+
+	companies := filepath.Join(dir, "companies.txt")
+	os.WriteFile(companies, []byte("# watch list\n12987654\n"), 0o644)
+
+	err := cmd.Execute([]string{
+		"stream", "officers", "--timepoint", "1", "--limit", "2",
+		"--checkpoint", filepath.Join(dir, "tp"),
+		"--sink", "file:" + filepath.Join(dir, "ndjson"),
+		"--sink", "webhook:" + hook.URL,
+		"--sink", "sqlite:" + filepath.Join(dir, "events.db"),
+		"--company-file", companies,
+		"--type", "changed",
+	})
+	if err != nil {
+		t.Fatalf("Execute(stream) error: %v", err)
+	}
+
+	if len(posted) != 2 {
+		t.Fatalf("webhook received %d events, want 2", len(posted))
+	}
+	for _, e := range posted {
+		if e.CompanyNumber() != "12987654" {
+			t.Errorf("event for %s passed the company filter", e.CompanyNumber())
+		}
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "ndjson", "officers-*.ndjson"))
+	if len(files) != 1 {
+		t.Errorf("ndjson files = %v, want one", files)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "events.db")); err != nil {
+		t.Errorf("sqlite database: %v", err)
+	}
+}
+
+func TestExecute_StreamUnknownSink(t *testing.T) {
+	useFake(t)
+
+	err := cmd.Execute([]string{"stream", "companies", "--sink", "kafka:events", "--checkpoint", filepath.Join(t.TempDir(), "tp")})
+	if cmd.ExitCode(err) != cmd.ExitCodeUsage {
+		t.Fatalf("ExitCode = %d, want %d (err: %v)", cmd.ExitCode(err), cmd.ExitCodeUsage, err)
+	}
+}
@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -76,6 +77,24 @@ func ConfigPath() (string, error) {
 	return filepath.Join(dir, "config.json"), nil
 }
 
+// CacheDir returns the directory holding cached API responses.
+func CacheDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+// StreamDir returns the directory holding Streaming API checkpoints.
+func StreamDir() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "stream"), nil
+}
+
 // ReadConfig reads the configuration file.
 func ReadConfig() (File, error) {
 	path, err := ConfigPath()
@@ -126,6 +145,10 @@ func WriteConfig(cfg File) error {
 	return nil
 }
 
+// ErrNoAPIKey is returned by APIKey when neither the environment nor the
+// config file holds a key.
+var ErrNoAPIKey = errors.New("no API key configured (set CH_API_KEY or run: ch auth set-key)")
+
 // APIKey returns the API key from config or environment.
 func APIKey() (string, error) {
 	if v := os.Getenv("CH_API_KEY"); v != "" {
@@ -136,7 +159,7 @@ func APIKey() (string, error) {
 		return "", err
 	}
 	if cfg.APIKey == "" {
-		return "", fmt.Errorf("no API key configured (set CH_API_KEY or run: ch auth set-key)")
+		return "", ErrNoAPIKey
 	}
 	return cfg.APIKey, nil
 }
@@ -75,6 +75,34 @@ func TestConfigPath(t *testing.T) {
 	}
 }
 
+func TestCacheDir(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("CH_CONFIG_DIR", tmp)
+
+	dir, err := config.CacheDir()
+	if err != nil {
+		t.Fatalf("CacheDir() error: %v", err)
+	}
+	want := filepath.Join(tmp, "cache")
+	if dir != want {
+		t.Errorf("CacheDir() = %q, want %q", dir, want)
+	}
+}
+
+func TestStreamDir(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("CH_CONFIG_DIR", tmp)
+
+	dir, err := config.StreamDir()
+	if err != nil {
+		t.Fatalf("StreamDir() error: %v", err)
+	}
+	want := filepath.Join(tmp, "stream")
+	if dir != want {
+		t.Errorf("StreamDir() = %q, want %q", dir, want)
+	}
+}
+
 func TestDir_FromEnv(t *testing.T) {
 	tmp := t.TempDir()
 	t.Setenv("CH_CONFIG_DIR", tmp)
@@ -0,0 +1,122 @@
+package ixbrl
+
+import "sort"
+
+// ConceptRef identifies facts reporting a figure. When Member is set the
+// fact must be qualified by a dimension with that member (e.g. Creditors
+// split by WithinOneYear); otherwise it must have no dimensions.
+type ConceptRef struct {
+	Name   string
+	Member string
+}
+
+// Measure is a headline figure and the concepts that report it, most
+// preferred first. Taxonomy versions and filers tag the same figure under
+// different concepts.
+type Measure struct {
+	Key      string
+	Label    string
+	Concepts []ConceptRef
+}
+
+// Measures are the headline figures extracted by Figures, in display order.
+var Measures = []Measure{
+	{"turnover", "Turnover", refs("TurnoverRevenue", "Turnover", "Revenue")},
+	{"gross_profit", "Gross profit", refs("GrossProfitLoss")},
+	{"operating_profit", "Operating profit", refs("OperatingProfitLoss")},
+	{"profit_before_tax", "Profit before tax", refs("ProfitLossOnOrdinaryActivitiesBeforeTax", "ProfitLossBeforeTax")},
+	{"profit", "Profit for the year", refs("ProfitLoss", "ProfitLossForPeriod")},
+	{"fixed_assets", "Fixed assets", refs("FixedAssets", "NoncurrentAssets")},
+	{"current_assets", "Current assets", refs("CurrentAssets")},
+	{"cash", "Cash", refs("CashBankOnHand", "CashBankInHand", "CashCashEquivalents")},
+	{"creditors_within_one_year", "Creditors < 1 year", []ConceptRef{
+		{Name: "CreditorsDueWithinOneYear"}, {Name: "Creditors", Member: "WithinOneYear"},
+	}},
+	{"creditors_after_one_year", "Creditors > 1 year", []ConceptRef{
+		{Name: "CreditorsDueAfterOneYear"}, {Name: "Creditors", Member: "AfterOneYear"},
+	}},
+	{"net_current_assets", "Net current assets", refs("NetCurrentAssetsLiabilities")},
+	{"net_assets", "Net assets", refs("NetAssetsLiabilities", "NetAssetsLiabilitiesIncludingPensionAssetLiability")},
+	{"equity", "Shareholders' funds", refs("Equity", "ShareholderFunds")},
+	{"employees", "Average employees", refs("AverageNumberEmployeesDuringPeriod", "AverageNumberEmployees")},
+}
+
+func refs(names ...string) []ConceptRef {
+	out := make([]ConceptRef, len(names))
+	for i, n := range names {
+		out[i] = ConceptRef{Name: n}
+	}
+	return out
+}
+
+func (r ConceptRef) matches(f Fact) bool {
+	if !f.Numeric() || f.Concept() != r.Name {
+		return false
+	}
+	if r.Member == "" {
+		return len(f.Dimensions) == 0
+	}
+	if len(f.Dimensions) != 1 {
+		return false
+	}
+	for _, m := range f.Dimensions {
+		return localName(m) == r.Member
+	}
+	return false
+}
+
+// Figure is the value of a Measure for one period.
+type Figure struct {
+	Key     string  `json:"key"`
+	Label   string  `json:"label"`
+	Concept string  `json:"concept"`
+	Period  Period  `json:"period"`
+	Value   float64 `json:"value"`
+	Unit    string  `json:"unit,omitempty"`
+}
+
+// Figures returns every Measure found in the document, once per period
+// date, in Measures order and newest period first. Accounts usually report
+// the prior year alongside the current one.
+func (d *Document) Figures() []Figure {
+	var out []Figure
+	for _, m := range Measures {
+		byDate := map[string]Figure{}
+		for _, ref := range m.Concepts {
+			for _, f := range d.Facts {
+				if !ref.matches(f) {
+					continue
+				}
+				if _, ok := byDate[f.Period.Date()]; ok {
+					continue
+				}
+				byDate[f.Period.Date()] = Figure{
+					Key:     m.Key,
+					Label:   m.Label,
+					Concept: f.Name,
+					Period:  f.Period,
+					Value:   *f.Value,
+					Unit:    f.Unit,
+				}
+			}
+		}
+		figs := make([]Figure, 0, len(byDate))
+		for _, fig := range byDate {
+			figs = append(figs, fig)
+		}
+		sort.Slice(figs, func(i, j int) bool { return figs[i].Period.Date() > figs[j].Period.Date() })
+		out = append(out, figs...)
+	}
+	return out
+}
+
+// Figure returns the value of the measure key for the period ending on
+// date.
+func (d *Document) Figure(key, date string) (Figure, bool) {
+	for _, fig := range d.Figures() {
+		if fig.Key == key && fig.Period.Date() == date {
+			return fig, true
+		}
+	}
+	return Figure{}, false
+}
@@ -0,0 +1,370 @@
+package ixbrl
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Namespaces of the inline XBRL elements the parser reads. Both the 1.0 and
+// 1.1 inline specifications are in use in Companies House filings.
+var ixNamespaces = map[string]bool{
+	"http://www.xbrl.org/2008/inlineXBRL": true,
+	"http://www.xbrl.org/2013/inlineXBRL": true,
+}
+
+const xbrliNamespace = "http://www.xbrl.org/2003/instance"
+
+// ErrNoFacts is returned by Parse when the document contains no tagged facts,
+// typically because it is plain XHTML or a scanned filing.
+var ErrNoFacts = errors.New("ixbrl: no tagged facts in document")
+
+// Period is the reporting period of a context: either an instant (balance
+// sheet figures) or a start and end date (profit and loss figures).
+type Period struct {
+	Instant string `json:"instant,omitempty"`
+	Start   string `json:"start,omitempty"`
+	End     string `json:"end,omitempty"`
+}
+
+// IsInstant reports whether p is a point in time rather than a duration.
+func (p Period) IsInstant() bool { return p.Instant != "" }
+
+// Date returns the instant, or the end date of a duration.
+func (p Period) Date() string {
+	if p.Instant != "" {
+		return p.Instant
+	}
+	return p.End
+}
+
+func (p Period) String() string {
+	if p.Instant != "" {
+		return p.Instant
+	}
+	return p.Start + " to " + p.End
+}
+
+// Context is an XBRL context: the entity, period and any dimensions a fact
+// applies to.
+type Context struct {
+	ID         string            `json:"id"`
+	Entity     string            `json:"entity,omitempty"`
+	Period     Period            `json:"period"`
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+}
+
+// Fact is one tagged value. Numeric facts (ix:nonFraction) carry Value with
+// scale and sign already applied; text facts (ix:nonNumeric) carry Text.
+type Fact struct {
+	Name       string            `json:"name"`
+	Context    string            `json:"context"`
+	Period     Period            `json:"period"`
+	Dimensions map[string]string `json:"dimensions,omitempty"`
+	Unit       string            `json:"unit,omitempty"`
+	Decimals   string            `json:"decimals,omitempty"`
+	Value      *float64          `json:"value,omitempty"`
+	Text       string            `json:"text,omitempty"`
+}
+
+// Concept returns the fact's concept name without its taxonomy prefix, e.g.
+// TurnoverRevenue for uk-core:TurnoverRevenue.
+func (f Fact) Concept() string { return localName(f.Name) }
+
+// Numeric reports whether the fact has a numeric value.
+func (f Fact) Numeric() bool { return f.Value != nil }
+
+// Document is a parsed iXBRL document. Warnings lists facts that were
+// skipped because their value could not be read, such as numbers in a
+// transformation format the parser does not support.
+type Document struct {
+	Contexts map[string]Context `json:"contexts"`
+	Units    map[string]string  `json:"units"`
+	Facts    []Fact             `json:"facts"`
+	Warnings []string           `json:"warnings,omitempty"`
+}
+
+// Text returns the first text fact for concept (matched without its prefix),
+// or "" if there is none.
+func (d *Document) Text(concept string) string {
+	for _, f := range d.Facts {
+		if !f.Numeric() && f.Concept() == concept && len(f.Dimensions) == 0 {
+			return f.Text
+		}
+	}
+	return ""
+}
+
+// EntityName returns the company name tagged in the document.
+func (d *Document) EntityName() string {
+	return d.Text("EntityCurrentLegalOrRegisteredName")
+}
+
+// ReportingDate returns the balance sheet date the document reports on: the
+// latest period date of any numeric fact.
+func (d *Document) ReportingDate() string {
+	var latest string
+	for _, f := range d.Facts {
+		if f.Numeric() && f.Period.Date() > latest {
+			latest = f.Period.Date()
+		}
+	}
+	return latest
+}
+
+// Parse reads an iXBRL document and returns its contexts, units and facts.
+// Facts repeated in several places in the document are returned once. A
+// fact whose value cannot be read is skipped and noted in Warnings rather
+// than failing the document.
+func Parse(r io.Reader) (*Document, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		raw   []rawFact
+		open  []*rawFact
+		ctxs  = map[string]xmlContext{}
+		units = map[string]string{}
+		depth int
+	)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("ixbrl: %w", err)
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			switch {
+			case isXBRLI(t.Name, "context"):
+				var c xmlContext
+				if err := dec.DecodeElement(&c, &t); err != nil {
+					return nil, fmt.Errorf("ixbrl: context: %w", err)
+				}
+				ctxs[c.ID] = c
+				continue
+			case isXBRLI(t.Name, "unit"):
+				var u xmlUnit
+				if err := dec.DecodeElement(&u, &t); err != nil {
+					return nil, fmt.Errorf("ixbrl: unit: %w", err)
+				}
+				units[u.ID] = u.String()
+				continue
+			case isIX(t.Name, "exclude"):
+				if err := dec.Skip(); err != nil {
+					return nil, fmt.Errorf("ixbrl: %w", err)
+				}
+				continue
+			}
+			depth++
+			if isIX(t.Name, "nonFraction") || isIX(t.Name, "nonNumeric") {
+				open = append(open, &rawFact{depth: depth, numeric: t.Name.Local == "nonFraction", attrs: t.Attr})
+			}
+		case xml.EndElement:
+			if n := len(open); n > 0 && open[n-1].depth == depth {
+				raw = append(raw, *open[n-1])
+				open = open[:n-1]
+			}
+			depth--
+		case xml.CharData:
+			for _, f := range open {
+				f.text.Write(t)
+			}
+		}
+	}
+
+	doc := &Document{Contexts: map[string]Context{}, Units: units}
+	for id, c := range ctxs {
+		doc.Contexts[id] = c.context()
+	}
+	seen := map[string]bool{}
+	for _, rf := range raw {
+		f, err := rf.fact(doc)
+		if err != nil {
+			doc.Warnings = append(doc.Warnings, err.Error())
+			continue
+		}
+		key := f.Name + "\x00" + f.Context + "\x00" + f.Unit + "\x00" + f.Text
+		if f.Value != nil {
+			key += "\x00" + strconv.FormatFloat(*f.Value, 'g', -1, 64)
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		doc.Facts = append(doc.Facts, f)
+	}
+	if len(doc.Facts) == 0 {
+		return nil, ErrNoFacts
+	}
+	return doc, nil
+}
+
+// rawFact is a fact element as read, before its attributes are resolved.
+type rawFact struct {
+	depth   int
+	numeric bool
+	attrs   []xml.Attr
+	text    strings.Builder
+}
+
+func (rf *rawFact) attr(name string) string {
+	for _, a := range rf.attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+func (rf *rawFact) fact(doc *Document) (Fact, error) {
+	f := Fact{
+		Name:     rf.attr("name"),
+		Context:  rf.attr("contextRef"),
+		Unit:     doc.Units[rf.attr("unitRef")],
+		Decimals: rf.attr("decimals"),
+	}
+	if c, ok := doc.Contexts[f.Context]; ok {
+		f.Period = c.Period
+		f.Dimensions = c.Dimensions
+	}
+	text := strings.Join(strings.Fields(rf.text.String()), " ")
+	if !rf.numeric {
+		f.Text = text
+		return f, nil
+	}
+	if rf.attr("nil") == "true" {
+		return f, nil
+	}
+	v, err := parseNumber(text, rf.attr("format"))
+	if err != nil {
+		return Fact{}, fmt.Errorf("ixbrl: %s in context %s: %w", f.Name, f.Context, err)
+	}
+	if s := rf.attr("scale"); s != "" {
+		scale, err := strconv.Atoi(s)
+		if err != nil {
+			return Fact{}, fmt.Errorf("ixbrl: %s: invalid scale %q", f.Name, s)
+		}
+		v *= math.Pow10(scale)
+	}
+	if rf.attr("sign") == "-" {
+		v = -v
+	}
+	f.Value = &v
+	return f, nil
+}
+
+// parseNumber converts the displayed text of a numeric fact to a number
+// according to its ix transformation format.
+func parseNumber(text, format string) (float64, error) {
+	text = strings.Map(func(r rune) rune {
+		if r == ' ' || r == ' ' {
+			return -1
+		}
+		return r
+	}, text)
+	switch localName(format) {
+	case "fixed-zero", "fixedzero", "zerodash", "numdash":
+		return 0, nil
+	case "numcommadecimal", "num-comma-decimal", "numspacecomma", "numdotcomma":
+		text = strings.ReplaceAll(text, ".", "")
+		text = strings.ReplaceAll(text, ",", ".")
+	default:
+		text = strings.ReplaceAll(text, ",", "")
+	}
+	switch text {
+	case "-", "–", "—":
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", text)
+	}
+	return v, nil
+}
+
+func isIX(n xml.Name, local string) bool {
+	return n.Local == local && (ixNamespaces[n.Space] || n.Space == "ix")
+}
+
+func isXBRLI(n xml.Name, local string) bool {
+	return n.Local == local && (n.Space == xbrliNamespace || n.Space == "xbrli")
+}
+
+func localName(qname string) string {
+	if i := strings.LastIndexByte(qname, ':'); i >= 0 {
+		return qname[i+1:]
+	}
+	return qname
+}
+
+type xmlContext struct {
+	ID     string `xml:"id,attr"`
+	Entity struct {
+		Identifier string      `xml:"identifier"`
+		Members    []xmlMember `xml:"segment>explicitMember"`
+	} `xml:"entity"`
+	Period struct {
+		Instant   string `xml:"instant"`
+		StartDate string `xml:"startDate"`
+		EndDate   string `xml:"endDate"`
+	} `xml:"period"`
+	Scenario []xmlMember `xml:"scenario>explicitMember"`
+}
+
+type xmlMember struct {
+	Dimension string `xml:"dimension,attr"`
+	Member    string `xml:",chardata"`
+}
+
+func (c xmlContext) context() Context {
+	ctx := Context{
+		ID:     c.ID,
+		Entity: strings.TrimSpace(c.Entity.Identifier),
+		Period: Period{
+			Instant: strings.TrimSpace(c.Period.Instant),
+			Start:   strings.TrimSpace(c.Period.StartDate),
+			End:     strings.TrimSpace(c.Period.EndDate),
+		},
+	}
+	for _, m := range append(c.Entity.Members, c.Scenario...) {
+		if ctx.Dimensions == nil {
+			ctx.Dimensions = map[string]string{}
+		}
+		ctx.Dimensions[m.Dimension] = strings.TrimSpace(m.Member)
+	}
+	return ctx
+}
+
+type xmlUnit struct {
+	ID          string   `xml:"id,attr"`
+	Measures    []string `xml:"measure"`
+	Numerator   []string `xml:"divide>unitNumerator>measure"`
+	Denominator []string `xml:"divide>unitDenominator>measure"`
+}
+
+// String renders the unit's measures without prefixes, e.g. GBP, pure or
+// GBP/shares.
+func (u xmlUnit) String() string {
+	join := func(ms []string) string {
+		names := make([]string, len(ms))
+		for i, m := range ms {
+			names[i] = localName(strings.TrimSpace(m))
+		}
+		sort.Strings(names)
+		return strings.Join(names, "*")
+	}
+	if len(u.Numerator) > 0 {
+		return join(u.Numerator) + "/" + join(u.Denominator)
+	}
+	return join(u.Measures)
+}
@@ -0,0 +1,144 @@
+package ixbrl_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/ixbrl"
+)
+
+func parseFile(t *testing.T, path string) *ixbrl.Document {
+	t.Helper()
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	doc, err := ixbrl.Parse(f)
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	return doc
+}
+
+func TestParse(t *testing.T) {
+	doc := parseFile(t, "testdata/small-company.xhtml")
+
+	if got := doc.EntityName(); got != "NORTHWIND ANALYTICS LTD" {
+		t.Errorf("EntityName() = %q", got)
+	}
+	if got := doc.ReportingDate(); got != "2023-11-30" {
+		t.Errorf("ReportingDate() = %q, want 2023-11-30", got)
+	}
+	if got := doc.Units["GBP"]; got != "GBP" {
+		t.Errorf("unit GBP = %q", got)
+	}
+	if c := doc.Contexts["cur-bs-within"]; c.Dimensions["core:MaturitiesOrExpirationPeriodsDimension"] != "core:WithinOneYear" {
+		t.Errorf("dimensions = %v", c.Dimensions)
+	}
+
+	var netAssets int
+	for _, f := range doc.Facts {
+		if f.Name == "core:NetAssetsLiabilities" && f.Context == "cur-bs" {
+			netAssets++
+		}
+		if f.Concept() == "GeneralDescriptionBasisMeasurementUsedInPreparingFinancialStatements" {
+			if strings.Contains(f.Text, "page 4") || !strings.HasSuffix(f.Text, "convention.") {
+				t.Errorf("text fact = %q, want ix:exclude content dropped", f.Text)
+			}
+		}
+	}
+	if netAssets != 1 {
+		t.Errorf("repeated fact returned %d times, want 1", netAssets)
+	}
+}
+
+func TestParse_Values(t *testing.T) {
+	doc := parseFile(t, "testdata/small-company.xhtml")
+
+	tests := []struct {
+		key, date string
+		want      float64
+		unit      string
+	}{
+		{"turnover", "2023-11-30", 1284000, "GBP"},
+		{"profit", "2022-11-30", -12905, "GBP"},
+		{"cash", "2023-11-30", 210440, "GBP"},
+		{"creditors_within_one_year", "2022-11-30", 40102, "GBP"},
+		{"net_assets", "2023-11-30", 149063, "GBP"},
+		{"employees", "2023-11-30", 7, "pure"},
+	}
+	for _, tt := range tests {
+		fig, ok := doc.Figure(tt.key, tt.date)
+		if !ok {
+			t.Errorf("Figure(%s, %s) not found", tt.key, tt.date)
+			continue
+		}
+		if fig.Value != tt.want || fig.Unit != tt.unit {
+			t.Errorf("Figure(%s, %s) = %v %s, want %v %s", tt.key, tt.date, fig.Value, fig.Unit, tt.want, tt.unit)
+		}
+	}
+	if _, ok := doc.Figure("creditors_after_one_year", "2023-11-30"); ok {
+		t.Error("creditors_after_one_year should not match the WithinOneYear member")
+	}
+
+	for _, f := range doc.Facts {
+		if f.Concept() == "ProvisionsForLiabilitiesBalanceSheetSubtotal" && (f.Value == nil || *f.Value != 0) {
+			t.Errorf("zerodash fact = %v, want 0", f.Value)
+		}
+	}
+}
+
+func TestFigures_Order(t *testing.T) {
+	doc := parseFile(t, "testdata/small-company.xhtml")
+	figs := doc.Figures()
+	if len(figs) < 2 || figs[0].Key != "turnover" || figs[0].Period.Date() != "2023-11-30" || figs[1].Period.Date() != "2022-11-30" {
+		t.Fatalf("Figures() starts %+v", figs[:2])
+	}
+}
+
+func TestParse_NoFacts(t *testing.T) {
+	_, err := ixbrl.Parse(strings.NewReader(`<html><body><p>Scanned accounts</p></body></html>`))
+	if !errors.Is(err, ixbrl.ErrNoFacts) {
+		t.Fatalf("Parse() error = %v, want ErrNoFacts", err)
+	}
+}
+
+func TestParse_CommaDecimal(t *testing.T) {
+	doc, err := ixbrl.Parse(strings.NewReader(`<html xmlns:ix="http://www.xbrl.org/2013/inlineXBRL" xmlns:xbrli="http://www.xbrl.org/2003/instance">
+<ix:header><ix:resources>
+<xbrli:context id="c"><xbrli:entity><xbrli:identifier scheme="x">1</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period></xbrli:context>
+<xbrli:unit id="EUR"><xbrli:measure>iso4217:EUR</xbrli:measure></xbrli:unit>
+</ix:resources></ix:header>
+<ix:nonFraction name="core:CashBankOnHand" contextRef="c" unitRef="EUR" decimals="2" format="ixt4:num-comma-decimal">1.234,56</ix:nonFraction>
+</html>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v := doc.Facts[0].Value; v == nil || *v != 1234.56 {
+		t.Fatalf("value = %v, want 1234.56", v)
+	}
+}
+
+func TestParse_SkipsUnreadableFacts(t *testing.T) {
+	doc, err := ixbrl.Parse(strings.NewReader(`<html xmlns:ix="http://www.xbrl.org/2013/inlineXBRL" xmlns:xbrli="http://www.xbrl.org/2003/instance">
+<ix:header><ix:resources>
+<xbrli:context id="c"><xbrli:entity><xbrli:identifier scheme="x">1</xbrli:identifier></xbrli:entity><xbrli:period><xbrli:instant>2024-12-31</xbrli:instant></xbrli:period></xbrli:context>
+<xbrli:unit id="GBP"><xbrli:measure>iso4217:GBP</xbrli:measure></xbrli:unit>
+</ix:resources></ix:header>
+<ix:nonFraction name="core:CashBankOnHand" contextRef="c" unitRef="GBP" decimals="0">12,500</ix:nonFraction>
+<ix:nonFraction name="core:AverageNumberEmployeesDuringPeriod" contextRef="c" unitRef="pure" decimals="0" format="ixt:numwordsen">twelve</ix:nonFraction>
+<ix:nonFraction name="core:NetAssetsLiabilities" contextRef="c" unitRef="GBP" decimals="0">40,000</ix:nonFraction>
+</html>`))
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+	if len(doc.Facts) != 2 {
+		t.Errorf("Facts = %d, want the 2 readable ones", len(doc.Facts))
+	}
+	if len(doc.Warnings) != 1 || !strings.Contains(doc.Warnings[0], "AverageNumberEmployeesDuringPeriod") {
+		t.Errorf("Warnings = %q", doc.Warnings)
+	}
+}
@@ -0,0 +1,132 @@
+package ixbrl
+
+import (
+	"math"
+	"sort"
+)
+
+// Filing is a parsed set of accounts and the filing transaction it came
+// from.
+type Filing struct {
+	TransactionID string
+	Document      *Document
+}
+
+// Year is the headline figures for one reporting date. TransactionID is the
+// filing whose own period this is; it is empty for a year known only from
+// the comparatives in the following year's accounts. Units holds each
+// value's unit (e.g. GBP, EUR, pure) where the filing declared one.
+type Year struct {
+	Date          string             `json:"date"`
+	TransactionID string             `json:"transaction_id,omitempty"`
+	Values        map[string]float64 `json:"values"`
+	Units         map[string]string  `json:"units,omitempty"`
+	Growth        map[string]float64 `json:"growth,omitempty"`
+}
+
+// Restatement records a figure that a later filing reported differently as
+// its prior-year comparative.
+type Restatement struct {
+	Key           string  `json:"key"`
+	Date          string  `json:"date"`
+	Reported      float64 `json:"reported"`
+	Restated      float64 `json:"restated"`
+	Unit          string  `json:"unit,omitempty"`
+	TransactionID string  `json:"transaction_id"`
+}
+
+// Series is a year-by-year view of a company's accounts, oldest year first.
+type Series struct {
+	Years        []Year        `json:"years"`
+	Restatements []Restatement `json:"restatements,omitempty"`
+}
+
+// BuildSeries merges filings, which must be ordered newest first, into at
+// most years Years (all if years <= 0). Where two filings report the same
+// year the later one wins, so restated comparatives replace the figures
+// originally filed; each such difference is listed in Restatements. Growth
+// is the percentage change on the previous year in the series, left out
+// where the two years report a figure in different currencies.
+func BuildSeries(filings []Filing, years int) Series {
+	var s Series
+	byDate := map[string]*Year{}
+	source := map[string]map[string]string{}
+	for _, f := range filings {
+		reporting := f.Document.ReportingDate()
+		for _, fig := range f.Document.Figures() {
+			date := fig.Period.Date()
+			y, ok := byDate[date]
+			if !ok {
+				y = &Year{Date: date, Values: map[string]float64{}}
+				byDate[date] = y
+				source[date] = map[string]string{}
+			}
+			if date == reporting && y.TransactionID == "" {
+				y.TransactionID = f.TransactionID
+			}
+			existing, ok := y.Values[fig.Key]
+			if !ok {
+				y.Values[fig.Key] = fig.Value
+				if fig.Unit != "" {
+					if y.Units == nil {
+						y.Units = map[string]string{}
+					}
+					y.Units[fig.Key] = fig.Unit
+				}
+				source[date][fig.Key] = f.TransactionID
+				continue
+			}
+			if date == reporting && existing != fig.Value {
+				s.Restatements = append(s.Restatements, Restatement{
+					Key:           fig.Key,
+					Date:          date,
+					Reported:      fig.Value,
+					Restated:      existing,
+					Unit:          y.Units[fig.Key],
+					TransactionID: source[date][fig.Key],
+				})
+			}
+		}
+	}
+
+	dates := make([]string, 0, len(byDate))
+	for d := range byDate {
+		dates = append(dates, d)
+	}
+	sort.Strings(dates)
+	if years > 0 && len(dates) > years {
+		dates = dates[len(dates)-years:]
+	}
+	for i, d := range dates {
+		y := *byDate[d]
+		if i > 0 {
+			prev := byDate[dates[i-1]]
+			for k, v := range y.Values {
+				p, ok := prev.Values[k]
+				if !ok || p == 0 || y.Units[k] != prev.Units[k] {
+					continue
+				}
+				if y.Growth == nil {
+					y.Growth = map[string]float64{}
+				}
+				y.Growth[k] = math.Round((v-p)/math.Abs(p)*1000) / 10
+			}
+		}
+		s.Years = append(s.Years, y)
+	}
+
+	sort.Slice(s.Restatements, func(i, j int) bool {
+		if s.Restatements[i].Date != s.Restatements[j].Date {
+			return s.Restatements[i].Date < s.Restatements[j].Date
+		}
+		return s.Restatements[i].Key < s.Restatements[j].Key
+	})
+	kept := s.Restatements[:0]
+	for _, r := range s.Restatements {
+		if len(dates) > 0 && r.Date >= dates[0] {
+			kept = append(kept, r)
+		}
+	}
+	s.Restatements = kept
+	return s
+}
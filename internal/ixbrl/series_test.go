@@ -0,0 +1,103 @@
+package ixbrl_test
+
+import (
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/ixbrl"
+)
+
+// accounts builds a document reporting turnover and net assets for the year
+// ending end, with prior-year comparatives for prevEnd.
+func accounts(end string, turnover, netAssets float64, prevEnd string, prevTurnover, prevNetAssets float64) *ixbrl.Document {
+	fact := func(name string, p ixbrl.Period, v float64) ixbrl.Fact {
+		return ixbrl.Fact{Name: name, Period: p, Unit: "GBP", Value: &v}
+	}
+	doc := &ixbrl.Document{Facts: []ixbrl.Fact{
+		fact("core:TurnoverRevenue", ixbrl.Period{End: end}, turnover),
+		fact("core:NetAssetsLiabilities", ixbrl.Period{Instant: end}, netAssets),
+	}}
+	if prevEnd != "" {
+		doc.Facts = append(doc.Facts,
+			fact("core:TurnoverRevenue", ixbrl.Period{End: prevEnd}, prevTurnover),
+			fact("core:NetAssetsLiabilities", ixbrl.Period{Instant: prevEnd}, prevNetAssets),
+		)
+	}
+	return doc
+}
+
+func TestBuildSeries(t *testing.T) {
+	filings := []ixbrl.Filing{
+		{TransactionID: "t2024", Document: accounts("2024-03-31", 1200, 500, "2023-03-31", 1000, 450)},
+		// 2023's own filing reported turnover of 980; the 2024 comparative restates it.
+		{TransactionID: "t2023", Document: accounts("2023-03-31", 980, 450, "2022-03-31", 800, 400)},
+		// 2021 is known only from the 2022 comparatives, whose own filing is missing.
+		{TransactionID: "t2022", Document: accounts("2022-03-31", 800, 400, "2021-03-31", 0, 420)},
+	}
+
+	s := ixbrl.BuildSeries(filings, 0)
+	if len(s.Years) != 4 {
+		t.Fatalf("len(Years) = %d, want 4", len(s.Years))
+	}
+	if s.Years[0].Date != "2021-03-31" || s.Years[0].TransactionID != "" {
+		t.Errorf("Years[0] = %+v, want 2021 from comparatives only", s.Years[0])
+	}
+	y2023 := s.Years[2]
+	if y2023.TransactionID != "t2023" || y2023.Values["turnover"] != 1000 {
+		t.Errorf("2023 = %+v, want restated turnover 1000 from t2023's year", y2023)
+	}
+	if y2023.Units["turnover"] != "GBP" {
+		t.Errorf("2023 units = %v, want turnover in GBP", y2023.Units)
+	}
+	if got := y2023.Growth["turnover"]; got != 25 {
+		t.Errorf("2023 turnover growth = %v, want 25", got)
+	}
+	if got := s.Years[3].Growth["net_assets"]; got != 11.1 {
+		t.Errorf("2024 net assets growth = %v, want 11.1", got)
+	}
+	if _, ok := s.Years[1].Growth["turnover"]; ok {
+		t.Error("growth on a zero base should be omitted")
+	}
+
+	if len(s.Restatements) != 1 {
+		t.Fatalf("Restatements = %+v, want one", s.Restatements)
+	}
+	r := s.Restatements[0]
+	if r.Key != "turnover" || r.Date != "2023-03-31" || r.Reported != 980 || r.Restated != 1000 || r.Unit != "GBP" || r.TransactionID != "t2024" {
+		t.Errorf("Restatement = %+v", r)
+	}
+}
+
+func TestBuildSeries_Limit(t *testing.T) {
+	filings := []ixbrl.Filing{
+		{TransactionID: "t2024", Document: accounts("2024-03-31", 1200, 500, "2023-03-31", 1000, 450)},
+		{TransactionID: "t2023", Document: accounts("2023-03-31", 980, 450, "2022-03-31", 800, 400)},
+	}
+	s := ixbrl.BuildSeries(filings, 2)
+	if len(s.Years) != 2 || s.Years[0].Date != "2023-03-31" {
+		t.Fatalf("Years = %+v, want the newest two", s.Years)
+	}
+	if s.Years[0].Growth != nil {
+		t.Errorf("oldest year growth = %v, want none", s.Years[0].Growth)
+	}
+	if len(s.Restatements) != 1 {
+		t.Errorf("Restatements = %+v", s.Restatements)
+	}
+}
+
+func TestBuildSeries_CurrencyChange(t *testing.T) {
+	filing := func(id, end, unit string, turnover float64) ixbrl.Filing {
+		return ixbrl.Filing{TransactionID: id, Document: &ixbrl.Document{Facts: []ixbrl.Fact{
+			{Name: "core:TurnoverRevenue", Period: ixbrl.Period{End: end}, Unit: unit, Value: &turnover},
+		}}}
+	}
+	s := ixbrl.BuildSeries([]ixbrl.Filing{
+		filing("t2024", "2024-03-31", "EUR", 1200),
+		filing("t2023", "2023-03-31", "GBP", 1000),
+	}, 0)
+	if len(s.Years) != 2 {
+		t.Fatalf("len(Years) = %d, want 2", len(s.Years))
+	}
+	if g, ok := s.Years[1].Growth["turnover"]; ok {
+		t.Errorf("growth from GBP to EUR = %v, want none", g)
+	}
+}
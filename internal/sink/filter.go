@@ -0,0 +1,34 @@
+package sink
+
+import (
+	"slices"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// Filter selects the events passed to a sink. Empty fields match
+// everything.
+type Filter struct {
+	// Companies restricts events to resources belonging to these company
+	// numbers. Events outside any company never match a company filter.
+	Companies []string
+	// Types restricts events by event type (changed or deleted).
+	Types []string
+	// Kinds restricts events by resource kind (e.g. company-profile,
+	// filing-history, company-psc-individual).
+	Kinds []string
+}
+
+// Match reports whether e passes the filter.
+func (f Filter) Match(e chapi.StreamEvent) bool {
+	if len(f.Companies) > 0 && !slices.Contains(f.Companies, e.CompanyNumber()) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Event.Type) {
+		return false
+	}
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, e.ResourceKind) {
+		return false
+	}
+	return true
+}
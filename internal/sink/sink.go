@@ -0,0 +1,112 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// Sink receives streamed events. Write must not return until the event is
+// durably handed over, since the caller advances its checkpoint afterwards.
+type Sink interface {
+	Write(ctx context.Context, e chapi.StreamEvent) error
+	Close() error
+}
+
+// Options configures the sinks created by Open.
+type Options struct {
+	// Stream is the stream the events come from. It prefixes rotated file
+	// names and fills the stream column of the SQLite table.
+	Stream string
+
+	// RotateBytes and RotateInterval start a new NDJSON file once the
+	// current one reaches that size or age. Zero disables either limit.
+	RotateBytes    int64
+	RotateInterval time.Duration
+
+	// WebhookRetries is the number of attempts per event for webhook sinks.
+	WebhookRetries int
+	// WebhookBackoff is the wait before the second attempt; it doubles
+	// for each later attempt. Zero means one second.
+	WebhookBackoff time.Duration
+	// HTTPClient sends webhook requests. Nil uses a client with a 30s
+	// timeout.
+	HTTPClient *http.Client
+	// UserAgent is sent with webhook requests.
+	UserAgent string
+
+	// Table is the SQLite table events are written to. Empty means
+	// "events".
+	Table string
+
+	// Stdout is where the stdout sink writes.
+	Stdout io.Writer
+}
+
+// Open creates the sink described by spec:
+//
+//	stdout            NDJSON on Options.Stdout
+//	file:DIR          rotating NDJSON files in DIR
+//	webhook:URL       an HTTP POST per event (http:// and https:// URLs may
+//	                  also be given bare)
+//	sqlite:PATH       rows in a table of a SQLite database
+func Open(spec string, opts Options) (Sink, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "stdout", "":
+		return NewWriter(opts.Stdout), nil
+	case "file":
+		return NewFile(arg, opts)
+	case "webhook":
+		return NewWebhook(arg, opts)
+	case "http", "https":
+		return NewWebhook(spec, opts)
+	case "sqlite":
+		return NewSQLite(arg, opts)
+	}
+	return nil, fmt.Errorf("unknown sink %q (want stdout, file:DIR, webhook:URL or sqlite:PATH)", spec)
+}
+
+// Writer writes events as NDJSON to an io.Writer.
+type Writer struct {
+	enc *json.Encoder
+}
+
+// NewWriter returns a sink writing one JSON event per line to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+func (s *Writer) Write(_ context.Context, e chapi.StreamEvent) error {
+	return s.enc.Encode(e)
+}
+
+func (s *Writer) Close() error { return nil }
+
+// Multi fans events out to several sinks in order. An event counts as
+// written only once every sink has accepted it.
+type Multi []Sink
+
+func (m Multi) Write(ctx context.Context, e chapi.StreamEvent) error {
+	for _, s := range m {
+		if err := s.Write(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m Multi) Close() error {
+	var errs []error
+	for _, s := range m {
+		errs = append(errs, s.Close())
+	}
+	return errors.Join(errs...)
+}
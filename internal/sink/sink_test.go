@@ -0,0 +1,200 @@
+package sink_test
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+	"github.com/anthonyencodeclub/ch/internal/sink"
+)
+
+func event(tp int64, uri, typ string) chapi.StreamEvent {
+	return chapi.StreamEvent{
+		ResourceKind: "company-profile",
+		ResourceURI:  uri,
+		ResourceID:   filepath.Base(uri),
+		Data:         json.RawMessage(`{"company_name":"TESCO PLC"}`),
+		Event:        chapi.StreamEventInfo{Timepoint: tp, PublishedAt: "2024-11-05T09:00:00", Type: typ},
+	}
+}
+
+func TestOpen_UnknownSink(t *testing.T) {
+	if _, err := sink.Open("kafka:events", sink.Options{}); err == nil {
+		t.Fatal("Open(kafka:) succeeded")
+	}
+	if _, err := sink.Open("webhook:not-a-url", sink.Options{}); err == nil {
+		t.Fatal("Open(webhook:not-a-url) succeeded")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	f := sink.Filter{Companies: []string{"00445790"}, Types: []string{"deleted"}}
+	tests := []struct {
+		e    chapi.StreamEvent
+		want bool
+	}{
+		{event(1, "/company/00445790/officers/abc", "deleted"), true},
+		{event(2, "/company/00445790", "changed"), false},
+		{event(3, "/company/12987654", "deleted"), false},
+		{event(4, "/disqualified-officers/natural/xyz", "deleted"), false},
+	}
+	for _, tt := range tests {
+		if got := f.Match(tt.e); got != tt.want {
+			t.Errorf("Match(%s %s) = %v, want %v", tt.e.ResourceURI, tt.e.Event.Type, got, tt.want)
+		}
+	}
+	if !(sink.Filter{}).Match(tests[3].e) {
+		t.Error("empty filter should match everything")
+	}
+}
+
+func TestFile_Rotates(t *testing.T) {
+	dir := t.TempDir()
+	s, err := sink.NewFile(dir, sink.Options{Stream: "companies", RotateBytes: 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	for tp := int64(1); tp <= 4; tp++ {
+		if err := s.Write(ctx, event(tp, "/company/00445790", "changed")); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "companies-*.ndjson"))
+	if len(files) < 2 {
+		t.Fatalf("files = %v, want rotation", files)
+	}
+	if filepath.Base(files[0]) != "companies-000000000001.ndjson" {
+		t.Errorf("first file = %s", files[0])
+	}
+	var lines int
+	for _, f := range files {
+		fh, err := os.Open(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		sc := bufio.NewScanner(fh)
+		for sc.Scan() {
+			var e chapi.StreamEvent
+			if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+				t.Errorf("%s: %v", f, err)
+			}
+			lines++
+		}
+		fh.Close()
+	}
+	if lines != 4 {
+		t.Errorf("events written = %d, want 4", lines)
+	}
+}
+
+func TestWebhook_Retries(t *testing.T) {
+	var calls atomic.Int32
+	var got chapi.StreamEvent
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.Header.Get("X-CH-Timepoint") != "42" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		b, _ := io.ReadAll(r.Body)
+		json.Unmarshal(b, &got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	s, err := sink.Open("webhook:"+srv.URL, sink.Options{WebhookRetries: 3, WebhookBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(context.Background(), event(42, "/company/00445790", "changed")); err != nil {
+		t.Fatalf("Write() error: %v", err)
+	}
+	if calls.Load() != 3 || got.Event.Timepoint != 42 {
+		t.Errorf("calls = %d, event = %+v", calls.Load(), got)
+	}
+}
+
+func TestWebhook_ClientErrorNotRetried(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s, err := sink.Open(srv.URL, sink.Options{WebhookRetries: 5, WebhookBackoff: time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Write(context.Background(), event(1, "/company/00445790", "changed")); err == nil {
+		t.Fatal("Write() succeeded on HTTP 400")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.db")
+	s, err := sink.Open("sqlite:"+path, sink.Options{Stream: "companies"})
+	if err != nil {
+		t.Fatalf("Open(sqlite) error: %v", err)
+	}
+	ctx := context.Background()
+	for _, e := range []chapi.StreamEvent{
+		event(1, "/company/00445790", "changed"),
+		event(2, "/company/12987654", "changed"),
+		event(2, "/company/12987654", "changed"), // replayed after a restart
+	} {
+		if err := s.Write(ctx, e); err != nil {
+			t.Fatalf("Write() error: %v", err)
+		}
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var n int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM events WHERE stream = 'companies'`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("rows = %d, want 2", n)
+	}
+	var company, data string
+	if err := db.QueryRow(`SELECT company_number, data FROM events WHERE timepoint = 1`).Scan(&company, &data); err != nil {
+		t.Fatal(err)
+	}
+	if company != "00445790" || data != `{"company_name":"TESCO PLC"}` {
+		t.Errorf("row = %s, %s", company, data)
+	}
+}
+
+func TestSQLite_InvalidTable(t *testing.T) {
+	_, err := sink.NewSQLite(filepath.Join(t.TempDir(), "x.db"), sink.Options{Table: "events; DROP TABLE x"})
+	if err == nil {
+		t.Fatal("NewSQLite accepted an invalid table name")
+	}
+}
@@ -0,0 +1,97 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"regexp"
+
+	_ "modernc.org/sqlite"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// validTable guards the table name, which cannot be passed as a query
+// parameter.
+var validTable = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// SQLite stores events as rows of a table, one per stream and timepoint.
+// Writing the same event twice replaces the row, so replays after a crash
+// are harmless.
+type SQLite struct {
+	db     *sql.DB
+	stream string
+	insert *sql.Stmt
+}
+
+// NewSQLite opens (creating if needed) the database at path and its events
+// table.
+func NewSQLite(path string, opts Options) (*SQLite, error) {
+	if path == "" {
+		return nil, fmt.Errorf("sqlite sink needs a database path (sqlite:PATH)")
+	}
+	table := opts.Table
+	if table == "" {
+		table = "events"
+	}
+	if !validTable.MatchString(table) {
+		return nil, fmt.Errorf("invalid sqlite table name %q", table)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite: %w", err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS ` + table + ` (
+		stream         TEXT    NOT NULL,
+		timepoint      INTEGER NOT NULL,
+		published_at   TEXT    NOT NULL,
+		event_type     TEXT    NOT NULL,
+		resource_kind  TEXT    NOT NULL,
+		resource_uri   TEXT    NOT NULL,
+		resource_id    TEXT    NOT NULL,
+		company_number TEXT,
+		fields_changed TEXT,
+		data           TEXT    NOT NULL,
+		PRIMARY KEY (stream, timepoint)
+	)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create table %s: %w", table, err)
+	}
+	if _, err := db.Exec(`CREATE INDEX IF NOT EXISTS ` + table + `_company ON ` + table + ` (company_number)`); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("create index: %w", err)
+	}
+	insert, err := db.Prepare(`INSERT OR REPLACE INTO ` + table + `
+		(stream, timepoint, published_at, event_type, resource_kind, resource_uri, resource_id, company_number, fields_changed, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`)
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("prepare insert: %w", err)
+	}
+	return &SQLite{db: db, stream: opts.Stream, insert: insert}, nil
+}
+
+func (s *SQLite) Write(ctx context.Context, e chapi.StreamEvent) error {
+	var company, fields any
+	if n := e.CompanyNumber(); n != "" {
+		company = n
+	}
+	if len(e.Event.FieldsChanged) > 0 {
+		b, _ := json.Marshal(e.Event.FieldsChanged)
+		fields = string(b)
+	}
+	_, err := s.insert.ExecContext(ctx, s.stream, e.Event.Timepoint, e.Event.PublishedAt, e.Event.Type,
+		e.ResourceKind, e.ResourceURI, e.ResourceID, company, fields, string(e.Data))
+	if err != nil {
+		return fmt.Errorf("sqlite insert: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLite) Close() error {
+	s.insert.Close()
+	return s.db.Close()
+}
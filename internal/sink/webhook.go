@@ -0,0 +1,105 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/anthonyencodeclub/ch/internal/chapi"
+)
+
+// Webhook POSTs each event as JSON to a URL. Network errors, 429 and 5xx
+// responses are retried with exponential backoff; any other non-2xx
+// response fails the event immediately.
+type Webhook struct {
+	url       string
+	client    *http.Client
+	attempts  int
+	backoff   time.Duration
+	userAgent string
+}
+
+// NewWebhook returns a webhook sink posting to rawURL.
+func NewWebhook(rawURL string, opts Options) (*Webhook, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("webhook sink needs an http(s) URL, got %q", rawURL)
+	}
+	w := &Webhook{
+		url:       rawURL,
+		client:    opts.HTTPClient,
+		attempts:  max(opts.WebhookRetries, 1),
+		backoff:   opts.WebhookBackoff,
+		userAgent: opts.UserAgent,
+	}
+	if w.client == nil {
+		w.client = &http.Client{Timeout: 30 * time.Second}
+	}
+	if w.backoff <= 0 {
+		w.backoff = time.Second
+	}
+	return w, nil
+}
+
+func (s *Webhook) Write(ctx context.Context, e chapi.StreamEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	var lastErr error
+	for attempt := range s.attempts {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.backoff << (attempt - 1)):
+			}
+		}
+		retry, err := s.post(ctx, body, e.Event.Timepoint)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+	return fmt.Errorf("webhook: %w", lastErr)
+}
+
+// post sends one attempt and reports whether a failure is worth retrying.
+func (s *Webhook) post(ctx context.Context, body []byte, timepoint int64) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-CH-Timepoint", strconv.FormatInt(timepoint, 10))
+	if s.userAgent != "" {
+		req.Header.Set("User-Agent", s.userAgent)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("request failed: %w", err)
+	}
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("HTTP %d from %s", resp.StatusCode, s.url)
+	}
+	return false, fmt.Errorf("HTTP %d from %s", resp.StatusCode, s.url)
+}
+
+func (s *Webhook) Close() error { return nil }
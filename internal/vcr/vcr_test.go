@@ -0,0 +1,107 @@
+package vcr_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/anthonyencodeclub/ch/internal/vcr"
+)
+
+func TestRecordThenReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"company_name": "TESCO PLC"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	path := filepath.Join(t.TempDir(), vcr.CassetteFile)
+	rec, err := vcr.NewRecorder(path, nil)
+	if err != nil {
+		t.Fatalf("NewRecorder() error: %v", err)
+	}
+	client := &http.Client{Transport: rec}
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/company/00445790?x=1", nil)
+	req.SetBasicAuth("secret-api-key", "")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if string(body) != `{"company_name": "TESCO PLC"}` {
+		t.Errorf("recorded pass-through body = %s", body)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	cassette, err := vcr.Load(path)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("interactions = %d, want 1", len(cassette.Interactions))
+	}
+	got := cassette.Interactions[0]
+	if got.Request.URL != "/company/00445790?x=1" {
+		t.Errorf("URL = %q, want path and query only", got.Request.URL)
+	}
+	if auth := got.Request.Header.Get("Authorization"); auth != "Basic REDACTED" {
+		t.Errorf("Authorization = %q, want scrubbed", auth)
+	}
+
+	rep, err := vcr.NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer() error: %v", err)
+	}
+	replayClient := &http.Client{Transport: rep}
+	resp, err = replayClient.Get("https://elsewhere.example/company/00445790?x=1")
+	if err != nil {
+		t.Fatalf("replay Get() error: %v", err)
+	}
+	body, _ = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != `{"company_name": "TESCO PLC"}` {
+		t.Errorf("replayed %d %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestReplayer_OrderAndMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), vcr.CassetteFile)
+	c := &vcr.Cassette{Interactions: []vcr.Interaction{
+		{Request: vcr.Request{Method: "GET", URL: "/a"}, Response: vcr.Response{StatusCode: 429}},
+		{Request: vcr.Request{Method: "GET", URL: "/a"}, Response: vcr.Response{StatusCode: 200, Body: "ok"}},
+	}}
+	if err := c.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+	rep, err := vcr.NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer() error: %v", err)
+	}
+	client := &http.Client{Transport: rep}
+
+	for _, want := range []int{429, 200, 200} {
+		resp, err := client.Get("http://api.test/a")
+		if err != nil {
+			t.Fatalf("Get() error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != want {
+			t.Errorf("status = %d, want %d", resp.StatusCode, want)
+		}
+	}
+
+	_, err = client.Get("http://api.test/b")
+	if err == nil || !strings.Contains(err.Error(), "no recorded response") {
+		t.Errorf("err = %v, want missing-interaction error", err)
+	}
+}